@@ -0,0 +1,61 @@
+package dataframe
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/v17/arrow/memory"
+	"github.com/go-gota/gota/series"
+)
+
+func TestDataFrame_ToFromArrow(t *testing.T) {
+	a := New(
+		series.New([]interface{}{"b", nil, "a"}, series.String, "name"),
+		series.New([]interface{}{1, 2, nil}, series.Int, "age"),
+		series.New([]interface{}{3.5, nil, 4.25}, series.Float, "score"),
+		series.New([]interface{}{nil, false, true}, series.Bool, "active"),
+	)
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	rec, err := a.ToArrow(pool)
+	if err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	defer rec.Release()
+
+	if rec.NumRows() != 3 || rec.NumCols() != 4 {
+		t.Errorf("Expected dimensions 3x4, got %dx%d", rec.NumRows(), rec.NumCols())
+	}
+	for j, col := range a.columns {
+		isNaN := col.IsNaN()
+		for i := range isNaN {
+			if rec.Column(j).IsNull(i) != isNaN[i] {
+				t.Errorf("Column: %s\nIndex: %d\nExpected null to be %v", col.Name, i, isNaN[i])
+			}
+		}
+	}
+
+	b := FromArrow(rec)
+	if err := b.Error(); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	if !reflect.DeepEqual(a.Types(), b.Types()) {
+		t.Errorf("Different types:\nA:%v\nB:%v", a.Types(), b.Types())
+	}
+	if !reflect.DeepEqual(a.Names(), b.Names()) {
+		t.Errorf("Different colnames:\nA:%v\nB:%v", a.Names(), b.Names())
+	}
+	if !reflect.DeepEqual(a.Records(), b.Records()) {
+		t.Errorf("Different values:\nA:%v\nB:%v", a.Records(), b.Records())
+	}
+	for j, col := range b.columns {
+		if !reflect.DeepEqual(a.columns[j].IsNaN(), col.IsNaN()) {
+			t.Errorf("Column: %s\nDifferent NaN:\nA:%v\nB:%v", col.Name, a.columns[j].IsNaN(), col.IsNaN())
+		}
+	}
+
+	if err := FromArrow(nil).Error(); err == nil {
+		t.Errorf("Expected error due to nil record")
+	}
+}
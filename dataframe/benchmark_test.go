@@ -1,6 +1,7 @@
 package dataframe_test
 
 import (
+	"math"
 	"math/rand"
 	"strconv"
 	"testing"
@@ -265,3 +266,53 @@ func BenchmarkDataFrame_Elem(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkDataFrame_CApplyParallel(b *testing.B) {
+	data := dataframe.New(generateSeries(10000, 10)...)
+	expensive := func(s series.Series1) series.Series1 {
+		floats := s.Float()
+		for i, f := range floats {
+			for k := 0; k < 20; k++ {
+				f = math.Sqrt(math.Abs(f) + 1)
+			}
+			floats[i] = f
+		}
+		return series.Floats(floats)
+	}
+	b.Run("10000x40_sequential", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			data.CApply(expensive)
+		}
+	})
+	for _, workers := range []int{2, 4, 8} {
+		b.Run("10000x40_workers_"+strconv.Itoa(workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				data.CApplyParallel(expensive, workers)
+			}
+		})
+	}
+}
+
+func BenchmarkDataFrame_CrossJoin(b *testing.B) {
+	b.ReportAllocs()
+	left := dataframe.New(generateSeries(1000, 1)...)
+	right := dataframe.New(generateSeries(1000, 1)...)
+	for i := 0; i < b.N; i++ {
+		left.CrossJoin(right)
+	}
+}
+
+func BenchmarkDataFrame_InnerJoin(b *testing.B) {
+	left := dataframe.New(
+		series.New(generateIntsN(10000, 5000), series.Int, "key"),
+		series.New(generateIntsN(10000, 100), series.Int, "A"),
+	)
+	right := dataframe.New(
+		series.New(generateIntsN(10000, 5000), series.Int, "key"),
+		series.New(generateIntsN(10000, 100), series.Int, "B"),
+	)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		left.InnerJoin(right, "key")
+	}
+}
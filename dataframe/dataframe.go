@@ -3,6 +3,7 @@
 package dataframe
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/go-gota/gota/series"
@@ -38,17 +39,46 @@ type DataFrame interface {
 	Error() error
 	Set(index series.Indexes, newvalues DataFrame) DataFrame
 	Subset(indexes series.Indexes) DataFrame
+	Head(n int) DataFrame
+	Tail(n int) DataFrame
+	Slice(start, end int) DataFrame
+	Sample(n int, seed int64) DataFrame
+	SampleFrac(frac float64, seed int64) DataFrame
+	Shuffle(seed int64) DataFrame
+	TrainTestSplit(frac float64, seed int64) (train, test DataFrame)
 	Select(indexes SelectIndexes) DataFrame
 	Drop(indexes SelectIndexes) DataFrame
+	SelectNumeric() DataFrame
+	SelectString() DataFrame
+	DropNA(subset ...string) DataFrame
+	Unique(subset ...string) DataFrame
+	Duplicated(subset ...string) series.BoolSeries
+	FillNA(value interface{}) DataFrame
+	FillNAByColumn(values map[string]interface{}) DataFrame
 	GroupBy(colnames ...string) *Groups
 	Rename(newname, oldname string) DataFrame
+	RenameAll(mapping map[string]string) DataFrame
+	Astype(colname string, t series.Type) DataFrame
+	Pivot(index, columns, values string) DataFrame
+	PivotTable(index, columns, values string, agg AggregationType) DataFrame
+	Melt(idVars []string, valueVars []string, varName, valueName string) DataFrame
 	CBind(dfb DataFrame) DataFrame
 	RBind(dfb DataFrame) DataFrame
 	Concat(dfb DataFrame) DataFrame
 	Mutate(s series.Series1) DataFrame
+	InsertColumn(pos int, s series.Series1) DataFrame
+	Coalesce(newname string, colnames ...string) DataFrame
+	SplitColumn(colname, sep string, newnames []string, options ...SplitOption) DataFrame
+	Explode(colname, sep string, options ...ExplodeOption) DataFrame
+	GetDummies(colname string, prefix string, options ...DummiesOption) DataFrame
+	RowNumber(name string, oneBased ...bool) DataFrame
 	FilterAggregation(agg Aggregation, filters ...F) DataFrame
+	FilterByFunc(f func(row map[string]interface{}) bool) DataFrame
 	Arrange(order ...Order) DataFrame
+	Reverse() DataFrame
+	Pipe(fns ...func(DataFrame) DataFrame) DataFrame
 	CApply(f func(series.Series1) series.Series1) DataFrame
+	CApplyParallel(f func(series.Series1) series.Series1, workers int) DataFrame
 	RApply(f func(series.Series1) series.Series1) DataFrame
 	Names() []string
 	Types() []series.Type
@@ -56,22 +86,47 @@ type DataFrame interface {
 	Dims() (int, int)
 	NRow() int
 	NCol() int
+	MemoryUsage() map[string]int
 	Col(colname string) series.Series1
 	InnerJoin(b DataFrame, keys ...string) DataFrame
+	InnerJoinCtx(ctx context.Context, b DataFrame, keys ...string) (DataFrame, error)
 	LeftJoin(b DataFrame, keys ...string) DataFrame
 	RightJoin(b DataFrame, keys ...string) DataFrame
 	OuterJoin(b DataFrame, keys ...string) DataFrame
+	InnerJoinOn(b DataFrame, leftKeys, rightKeys []string, options ...JoinOption) DataFrame
+	LeftJoinOn(b DataFrame, leftKeys, rightKeys []string, options ...JoinOption) DataFrame
+	RightJoinOn(b DataFrame, leftKeys, rightKeys []string, options ...JoinOption) DataFrame
+	OuterJoinOn(b DataFrame, leftKeys, rightKeys []string, options ...JoinOption) DataFrame
 	CrossJoin(b DataFrame) DataFrame
+	SemiJoin(b DataFrame, keys ...string) DataFrame
+	AntiJoin(b DataFrame, keys ...string) DataFrame
+	Equal(other DataFrame) bool
+	EqualApprox(other DataFrame, tol float64) bool
+	Hash() uint64
 	Records() [][]string
 	Maps() []map[string]interface{}
+	FillStructs(out interface{}) error
+	ToMatrix() (Matrix, error)
+	Rows() func(yield func(int, map[string]interface{}) bool)
 	Elem(r, c int) series.Element
+	ElemSafe(r, c int) (series.Element, error)
+	At(row int, colname string) (series.Element, error)
+	SetAt(row int, colname string, value interface{}) DataFrame
 	Describe() DataFrame
+	NullCount() map[string]int
+	Info() DataFrame
+	DescribeQuantiles(qs ...float64) DataFrame
+	Corr() DataFrame
+	Cov() DataFrame
 	Columns() []series.Series1
 	ColIndex(s string) int
 }
 
 type GroupedDataFrame interface {
 	Aggregation(typs []AggregationType, colnames []string) DataFrame
+	AggregationCtx(ctx context.Context, typs []AggregationType, colnames []string) (DataFrame, error)
+	AggregationMulti(specs map[string][]AggregationType) DataFrame
+	Count() DataFrame
 	GetGroups() map[string]DataFrame
 }
 
@@ -83,6 +138,46 @@ type F struct {
 	Comparando interface{}
 }
 
+// BetweenOption is the type used to configure the bounds of the filters
+// returned by Between.
+type BetweenOption func(*betweenOptions)
+
+type betweenOptions struct {
+	lowComparator  series.Comparator
+	highComparator series.Comparator
+}
+
+// Exclusive sets whether the lower and upper bounds of Between are excluded
+// from the range. By default both bounds are included.
+func Exclusive(low, high bool) BetweenOption {
+	return func(c *betweenOptions) {
+		if low {
+			c.lowComparator = series.Greater
+		}
+		if high {
+			c.highComparator = series.Less
+		}
+	}
+}
+
+// Between returns the filters that keep the rows whose values on the given
+// column lie between low and high, which have to be aggregated with And:
+//
+//	df.FilterAggregation(dataframe.And, dataframe.Between("age", 18, 65)...)
+func Between(colname string, low, high interface{}, options ...BetweenOption) []F {
+	cfg := betweenOptions{
+		lowComparator:  series.GreaterEq,
+		highComparator: series.LessEq,
+	}
+	for _, option := range options {
+		option(&cfg)
+	}
+	return []F{
+		{Colname: colname, Comparator: cfg.lowComparator, Comparando: low},
+		{Colname: colname, Comparator: cfg.highComparator, Comparando: high},
+	}
+}
+
 const KEY_ERROR = "KEY_ERROR"
 
 // AggregationType Aggregation method type
@@ -108,6 +203,10 @@ func (a Aggregation) String() string {
 		return "or"
 	case And:
 		return "and"
+	case AndNot:
+		return "and not"
+	case Xor:
+		return "xor"
 	}
 	return fmt.Sprintf("unknown aggragation %d", a)
 }
@@ -117,6 +216,10 @@ const (
 	Or Aggregation = iota
 	// And aggregates filters with logical and
 	And
+	// AndNot keeps the rows matching the previous filters but not the next one
+	AndNot
+	// Xor aggregates filters with logical exclusive or
+	Xor
 )
 
 // Matrix is an interface which is compatible with gonum's mat.Matrix interface
@@ -2,15 +2,22 @@ package dataframe
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"math/rand"
 	"reflect"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"math"
 
 	"github.com/go-gota/gota/series"
+	"gonum.org/v1/gonum/mat"
 )
 
 // compareFloats compares floating point values up to the number of digits specified.
@@ -2021,6 +2028,54 @@ c,3,1
 			`NaN,1,3
 b,2,2
 c,3,1
+`,
+		},
+		{ // Test: 3
+			LoadRecords(
+				[][]string{
+					{"COL.1", "COL.2", "COL.3"},
+					{"NaN", "1", "3.5"},
+					{"b", "NaN", "2.5"},
+					{"c", "3", "NaN"},
+				},
+			),
+			[]WriteOption{NARepr("")},
+			`COL.1,COL.2,COL.3
+,1,3.500000
+b,,2.500000
+c,3,
+`,
+		},
+		{ // Test: 4
+			LoadRecords(
+				[][]string{
+					{"COL.1", "COL.2", "COL.3"},
+					{"NaN", "1", "3.5"},
+					{"b", "NaN", "2.5"},
+					{"c", "3", "NaN"},
+				},
+			),
+			[]WriteOption{NARepr("null")},
+			`COL.1,COL.2,COL.3
+null,1,3.500000
+b,null,2.500000
+c,3,null
+`,
+		},
+		{ // Test: 5
+			LoadRecords(
+				[][]string{
+					{"COL.1", "COL.2", "COL.3"},
+					{"NaN", "1", "3"},
+					{"b", "2", "2"},
+					{"c", "3", "1"},
+				},
+			),
+			[]WriteOption{Columns("COL.3", "COL.1")},
+			`COL.3,COL.1
+3,NaN
+2,b
+1,c
 `,
 		},
 	}
@@ -2035,6 +2090,16 @@ c,3,1
 			t.Errorf("Test: %d\nExpected: %v\nreceived: %v", i, tc.expected, buf.String())
 		}
 	}
+
+	a := LoadRecords(
+		[][]string{
+			{"COL.1", "COL.2"},
+			{"a", "1"},
+		},
+	)
+	if err := a.WriteCSV(new(bytes.Buffer), Columns("COL.1", "COL.9")); err == nil {
+		t.Errorf("Expected error due to unknown column name")
+	}
 }
 
 func TestDataFrame_WriteJSON(t *testing.T) {
@@ -3000,3 +3065,3824 @@ func TestGroups_GetGroups(t *testing.T) {
 		t.Fatalf("Expected to get 3 groups, got %d", len(groupNames))
 	}
 }
+
+func TestDataFrame_DropNA(t *testing.T) {
+	a := New(
+		series.New([]string{"b", "NaN", "b", "c", "d"}, series.String, "COL.1"),
+		series.New([]string{"1", "2", "NaN", "5", "4"}, series.Int, "COL.2"),
+		series.New([]float64{3.0, 4.0, 5.3, 3.2, 1.2}, series.Float, "COL.3"),
+	)
+	noNaN := New(
+		series.New([]string{"b", "a"}, series.String, "COL.1"),
+		series.New([]int{1, 2}, series.Int, "COL.2"),
+	)
+	table := []struct {
+		df     DataFrame
+		subset []string
+		expDf  DataFrame
+	}{
+		{
+			a,
+			nil,
+			New(
+				series.New([]string{"b", "c", "d"}, series.String, "COL.1"),
+				series.New([]int{1, 5, 4}, series.Int, "COL.2"),
+				series.New([]float64{3.0, 3.2, 1.2}, series.Float, "COL.3"),
+			),
+		},
+		{
+			a,
+			[]string{"COL.1"},
+			New(
+				series.New([]string{"b", "b", "c", "d"}, series.String, "COL.1"),
+				series.New([]string{"1", "NaN", "5", "4"}, series.Int, "COL.2"),
+				series.New([]float64{3.0, 5.3, 3.2, 1.2}, series.Float, "COL.3"),
+			),
+		},
+		{
+			a,
+			[]string{"COL.2", "COL.3"},
+			New(
+				series.New([]string{"b", "NaN", "c", "d"}, series.String, "COL.1"),
+				series.New([]int{1, 2, 5, 4}, series.Int, "COL.2"),
+				series.New([]float64{3.0, 4.0, 3.2, 1.2}, series.Float, "COL.3"),
+			),
+		},
+		{
+			noNaN,
+			nil,
+			noNaN,
+		},
+	}
+
+	for i, tc := range table {
+		b := tc.df.DropNA(tc.subset...)
+
+		if err := b.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+		}
+		// Check that the types are the same between both DataFrames
+		if !reflect.DeepEqual(tc.expDf.Types(), b.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, tc.expDf.Types(), b.Types())
+		}
+		// Check that the colnames are the same between both DataFrames
+		if !reflect.DeepEqual(tc.expDf.Names(), b.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expDf.Names(), b.Names())
+		}
+		// Check that the values are the same between both DataFrames
+		if !reflect.DeepEqual(tc.expDf.Records(), b.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expDf.Records(), b.Records())
+		}
+	}
+
+	b := a.DropNA("COL.4")
+	if b.Error() == nil {
+		t.Errorf("Expected error for unknown column name")
+	}
+}
+
+func TestDataFrame_FillNA(t *testing.T) {
+	a := New(
+		series.New([]string{"b", "NaN", "b", "NaN"}, series.String, "COL.1"),
+		series.New([]string{"1", "NaN", "NaN", "5"}, series.Int, "COL.2"),
+		series.New([]float64{3.0, 4.0, 5.3, 3.2}, series.Float, "COL.3"),
+	)
+	table := []struct {
+		df    DataFrame
+		expDf DataFrame
+	}{
+		{
+			a.FillNA(0),
+			New(
+				series.New([]string{"b", "0", "b", "0"}, series.String, "COL.1"),
+				series.New([]int{1, 0, 0, 5}, series.Int, "COL.2"),
+				series.New([]float64{3.0, 4.0, 5.3, 3.2}, series.Float, "COL.3"),
+			),
+		},
+		{
+			a.FillNAByColumn(map[string]interface{}{
+				"COL.1": "unknown",
+				"COL.2": 0,
+			}),
+			New(
+				series.New([]string{"b", "unknown", "b", "unknown"}, series.String, "COL.1"),
+				series.New([]int{1, 0, 0, 5}, series.Int, "COL.2"),
+				series.New([]float64{3.0, 4.0, 5.3, 3.2}, series.Float, "COL.3"),
+			),
+		},
+		{
+			a.FillNAByColumn(map[string]interface{}{
+				"COL.2": 7,
+			}),
+			New(
+				series.New([]string{"b", "NaN", "b", "NaN"}, series.String, "COL.1"),
+				series.New([]int{1, 7, 7, 5}, series.Int, "COL.2"),
+				series.New([]float64{3.0, 4.0, 5.3, 3.2}, series.Float, "COL.3"),
+			),
+		},
+	}
+
+	for i, tc := range table {
+		b := tc.df
+
+		if err := b.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+		}
+		// Check that the types are the same between both DataFrames
+		if !reflect.DeepEqual(tc.expDf.Types(), b.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, tc.expDf.Types(), b.Types())
+		}
+		// Check that the colnames are the same between both DataFrames
+		if !reflect.DeepEqual(tc.expDf.Names(), b.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expDf.Names(), b.Names())
+		}
+		// Check that the values are the same between both DataFrames
+		if !reflect.DeepEqual(tc.expDf.Records(), b.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expDf.Records(), b.Records())
+		}
+	}
+
+	// Check that the receiver has not been modified
+	expected := [][]string{
+		{"COL.1", "COL.2", "COL.3"},
+		{"b", "1", "3.000000"},
+		{"NaN", "NaN", "4.000000"},
+		{"b", "NaN", "5.300000"},
+		{"NaN", "5", "3.200000"},
+	}
+	if !reflect.DeepEqual(expected, a.Records()) {
+		t.Errorf("Receiver modified:\nA:%v\nB:%v", expected, a.Records())
+	}
+
+	// Values that can't be converted to the column type return an error
+	if b := a.FillNA("unknown"); b.Error() == nil {
+		t.Errorf("Expected error when filling an int column with a string")
+	}
+	if b := a.FillNAByColumn(map[string]interface{}{"COL.4": 0}); b.Error() == nil {
+		t.Errorf("Expected error for unknown column name")
+	}
+}
+
+func TestDataFrame_HeadTail(t *testing.T) {
+	a := New(
+		series.New([]string{"b", "a", "b", "c", "d"}, series.String, "COL.1"),
+		series.New([]int{1, 2, 4, 5, 4}, series.Int, "COL.2"),
+		series.New([]float64{3.0, 4.0, 5.3, 3.2, 1.2}, series.Float, "COL.3"),
+	)
+	table := []struct {
+		df    DataFrame
+		expDf DataFrame
+	}{
+		{
+			a.Head(2),
+			New(
+				series.New([]string{"b", "a"}, series.String, "COL.1"),
+				series.New([]int{1, 2}, series.Int, "COL.2"),
+				series.New([]float64{3.0, 4.0}, series.Float, "COL.3"),
+			),
+		},
+		{
+			a.Tail(2),
+			New(
+				series.New([]string{"c", "d"}, series.String, "COL.1"),
+				series.New([]int{5, 4}, series.Int, "COL.2"),
+				series.New([]float64{3.2, 1.2}, series.Float, "COL.3"),
+			),
+		},
+		{
+			a.Head(10),
+			a,
+		},
+		{
+			a.Tail(10),
+			a,
+		},
+		{
+			a.Head(0),
+			New(
+				series.New([]string{}, series.String, "COL.1"),
+				series.New([]int{}, series.Int, "COL.2"),
+				series.New([]float64{}, series.Float, "COL.3"),
+			),
+		},
+		{
+			a.Tail(-1),
+			New(
+				series.New([]string{}, series.String, "COL.1"),
+				series.New([]int{}, series.Int, "COL.2"),
+				series.New([]float64{}, series.Float, "COL.3"),
+			),
+		},
+	}
+
+	for i, tc := range table {
+		b := tc.df
+
+		if err := b.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+		}
+		if tc.expDf.NRow() != b.NRow() {
+			t.Errorf("Test: %d\nDifferent number of rows:\nA:%v\nB:%v", i, tc.expDf.NRow(), b.NRow())
+		}
+		// Check that the types are the same between both DataFrames
+		if !reflect.DeepEqual(tc.expDf.Types(), b.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, tc.expDf.Types(), b.Types())
+		}
+		// Check that the colnames are the same between both DataFrames
+		if !reflect.DeepEqual(tc.expDf.Names(), b.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expDf.Names(), b.Names())
+		}
+		// Check that the values are the same between both DataFrames
+		if !reflect.DeepEqual(tc.expDf.Records(), b.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expDf.Records(), b.Records())
+		}
+	}
+}
+
+func TestDataFrame_Sample(t *testing.T) {
+	a := New(
+		series.New([]string{"a", "b", "c", "d", "e", "f", "g", "h"}, series.String, "COL.1"),
+		series.New([]int{1, 2, 3, 4, 5, 6, 7, 8}, series.Int, "COL.2"),
+	)
+
+	b := a.Sample(4, 42)
+	if err := b.Error(); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	if b.NRow() != 4 {
+		t.Errorf("Expected 4 rows, received %d", b.NRow())
+	}
+	if !reflect.DeepEqual(a.Names(), b.Names()) || !reflect.DeepEqual(a.Types(), b.Types()) {
+		t.Errorf("Different columns:\nA:%v %v\nB:%v %v", a.Names(), a.Types(), b.Names(), b.Types())
+	}
+
+	// The same seed yields the same rows in the same order
+	c := a.Sample(4, 42)
+	if !reflect.DeepEqual(b.Records(), c.Records()) {
+		t.Errorf("Same seed, different values:\nA:%v\nB:%v", b.Records(), c.Records())
+	}
+	// A different seed yields a different sample
+	d := a.Sample(4, 7)
+	if reflect.DeepEqual(b.Records(), d.Records()) {
+		t.Errorf("Different seeds, same values:\nA:%v\nB:%v", b.Records(), d.Records())
+	}
+
+	// Rows are not repeated and are kept aligned
+	seen := map[string]bool{}
+	for _, row := range b.Records()[1:] {
+		if seen[row[0]] {
+			t.Errorf("Row %v sampled more than once", row)
+		}
+		seen[row[0]] = true
+		if int(row[0][0]-'a'+1) != mustAtoi(row[1]) {
+			t.Errorf("Row %v is not aligned", row)
+		}
+	}
+
+	if e := a.SampleFrac(0.5, 42); e.NRow() != 4 {
+		t.Errorf("Expected 4 rows, received %d", e.NRow())
+	}
+	if e := a.Sample(9, 42); e.Error() == nil {
+		t.Errorf("Expected error when sampling more rows than available")
+	}
+	if e := a.SampleFrac(1.5, 42); e.Error() == nil {
+		t.Errorf("Expected error for fraction out of range")
+	}
+}
+
+func mustAtoi(s string) int {
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		panic(err)
+	}
+	return i
+}
+
+func TestDataFrame_Pivot(t *testing.T) {
+	a := New(
+		series.New([]string{"x", "x", "y", "y", "z"}, series.String, "id"),
+		series.New([]string{"a", "b", "a", "b", "a"}, series.String, "key"),
+		series.New([]float64{1.0, 2.0, 3.0, 4.0, 5.0}, series.Float, "value"),
+	)
+	b := a.Pivot("id", "key", "value")
+	if err := b.Error(); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	expDf := New(
+		series.New([]string{"x", "y", "z"}, series.String, "id"),
+		series.New([]float64{1.0, 3.0, 5.0}, series.Float, "a"),
+		series.New([]string{"2.0", "4.0", "NaN"}, series.Float, "b"),
+	)
+	if r, c := b.Dims(); r != 3 || c != 3 {
+		t.Errorf("Expected dimensions 3x3, received %dx%d", r, c)
+	}
+	if !reflect.DeepEqual(expDf.Types(), b.Types()) {
+		t.Errorf("Different types:\nA:%v\nB:%v", expDf.Types(), b.Types())
+	}
+	if !reflect.DeepEqual(expDf.Names(), b.Names()) {
+		t.Errorf("Different colnames:\nA:%v\nB:%v", expDf.Names(), b.Names())
+	}
+	if !reflect.DeepEqual(expDf.Records(), b.Records()) {
+		t.Errorf("Different values:\nA:%v\nB:%v", expDf.Records(), b.Records())
+	}
+
+	// Duplicated index/column pairs are an error
+	c := a.RBind(a.Subset([]int{0}))
+	if d := c.Pivot("id", "key", "value"); d.Error() == nil {
+		t.Errorf("Expected error for duplicated entries")
+	}
+	if d := a.Pivot("id", "key", "unknown"); d.Error() == nil {
+		t.Errorf("Expected error for unknown column name")
+	}
+}
+
+func TestDataFrame_Melt(t *testing.T) {
+	a := New(
+		series.New([]string{"x", "y"}, series.String, "id"),
+		series.New([]int{1, 2}, series.Int, "a"),
+		series.New([]int{3, 4}, series.Int, "b"),
+		series.New([]float64{5.5, 6.5}, series.Float, "c"),
+	)
+	table := []struct {
+		valueVars []string
+		expDf     DataFrame
+	}{
+		{
+			[]string{"a", "b"},
+			New(
+				series.New([]string{"x", "y", "x", "y"}, series.String, "id"),
+				series.New([]string{"a", "a", "b", "b"}, series.String, "variable"),
+				series.New([]int{1, 2, 3, 4}, series.Int, "value"),
+			),
+		},
+		{
+			nil,
+			New(
+				series.New([]string{"x", "y", "x", "y", "x", "y"}, series.String, "id"),
+				series.New([]string{"a", "a", "b", "b", "c", "c"}, series.String, "variable"),
+				series.New([]string{"1", "2", "3", "4", "5.500000", "6.500000"}, series.String, "value"),
+			),
+		},
+	}
+
+	for i, tc := range table {
+		b := a.Melt([]string{"id"}, tc.valueVars, "variable", "value")
+
+		if err := b.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+		}
+		if tc.expDf.NRow() != b.NRow() {
+			t.Errorf("Test: %d\nDifferent number of rows:\nA:%v\nB:%v", i, tc.expDf.NRow(), b.NRow())
+		}
+		// Check that the types are the same between both DataFrames
+		if !reflect.DeepEqual(tc.expDf.Types(), b.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, tc.expDf.Types(), b.Types())
+		}
+		// Check that the colnames are the same between both DataFrames
+		if !reflect.DeepEqual(tc.expDf.Names(), b.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expDf.Names(), b.Names())
+		}
+		// Check that the values are the same between both DataFrames
+		if !reflect.DeepEqual(tc.expDf.Records(), b.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expDf.Records(), b.Records())
+		}
+	}
+
+	if b := a.Melt([]string{"id"}, []string{"d"}, "variable", "value"); b.Error() == nil {
+		t.Errorf("Expected error for unknown column name")
+	}
+}
+
+func TestDataFrame_SemiAntiJoin(t *testing.T) {
+	a := LoadRecords(
+		[][]string{
+			{"A", "B", "C", "D"},
+			{"1", "a", "5.1", "true"},
+			{"2", "b", "6.0", "true"},
+			{"3", "c", "6.0", "false"},
+			{"1", "d", "7.1", "false"},
+		},
+	)
+	b := LoadRecords(
+		[][]string{
+			{"A", "F", "D"},
+			{"1", "1", "true"},
+			{"4", "2", "false"},
+			{"2", "8", "false"},
+			{"5", "9", "false"},
+		},
+	)
+	c := LoadRecords(
+		[][]string{
+			{"A", "D"},
+			{"7", "true"},
+		},
+	)
+	table := []struct {
+		df      DataFrame
+		keys    []string
+		expSemi [][]string
+		expAnti [][]string
+	}{
+		{
+			b,
+			[]string{"A"},
+			[][]string{
+				{"A", "B", "C", "D"},
+				{"1", "a", "5.100000", "true"},
+				{"2", "b", "6.000000", "true"},
+				{"1", "d", "7.100000", "false"},
+			},
+			[][]string{
+				{"A", "B", "C", "D"},
+				{"3", "c", "6.000000", "false"},
+			},
+		},
+		{
+			b,
+			[]string{"A", "D"},
+			[][]string{
+				{"A", "B", "C", "D"},
+				{"1", "a", "5.100000", "true"},
+			},
+			[][]string{
+				{"A", "B", "C", "D"},
+				{"2", "b", "6.000000", "true"},
+				{"3", "c", "6.000000", "false"},
+				{"1", "d", "7.100000", "false"},
+			},
+		},
+		{
+			c,
+			[]string{"A", "D"},
+			[][]string{
+				{"A", "B", "C", "D"},
+			},
+			[][]string{
+				{"A", "B", "C", "D"},
+				{"1", "a", "5.100000", "true"},
+				{"2", "b", "6.000000", "true"},
+				{"3", "c", "6.000000", "false"},
+				{"1", "d", "7.100000", "false"},
+			},
+		},
+	}
+	for i, tc := range table {
+		semi := a.SemiJoin(tc.df, tc.keys...)
+		if err := semi.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+		}
+		if !reflect.DeepEqual(a.Types(), semi.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, a.Types(), semi.Types())
+		}
+		if !reflect.DeepEqual(tc.expSemi, semi.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expSemi, semi.Records())
+		}
+
+		anti := a.AntiJoin(tc.df, tc.keys...)
+		if err := anti.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+		}
+		if !reflect.DeepEqual(a.Types(), anti.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, a.Types(), anti.Types())
+		}
+		if !reflect.DeepEqual(tc.expAnti, anti.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expAnti, anti.Records())
+		}
+	}
+
+	if d := a.SemiJoin(b, "F"); d.Error() == nil {
+		t.Errorf("Expected error for missing key on left DataFrame")
+	}
+	if d := a.AntiJoin(b); d.Error() == nil {
+		t.Errorf("Expected error for missing keys")
+	}
+}
+
+func TestDataFrame_Unique(t *testing.T) {
+	a := New(
+		series.New([]string{"b", "a", "b", "a", "b"}, series.String, "COL.1"),
+		series.New([]int{1, 2, 1, 3, 1}, series.Int, "COL.2"),
+		series.New([]float64{3.0, 4.0, 3.0, 3.2, 1.2}, series.Float, "COL.3"),
+	)
+	table := []struct {
+		subset []string
+		expDup []bool
+		expDf  DataFrame
+	}{
+		{
+			nil,
+			[]bool{false, false, true, false, false},
+			New(
+				series.New([]string{"b", "a", "a", "b"}, series.String, "COL.1"),
+				series.New([]int{1, 2, 3, 1}, series.Int, "COL.2"),
+				series.New([]float64{3.0, 4.0, 3.2, 1.2}, series.Float, "COL.3"),
+			),
+		},
+		{
+			[]string{"COL.1", "COL.2"},
+			[]bool{false, false, true, false, true},
+			New(
+				series.New([]string{"b", "a", "a"}, series.String, "COL.1"),
+				series.New([]int{1, 2, 3}, series.Int, "COL.2"),
+				series.New([]float64{3.0, 4.0, 3.2}, series.Float, "COL.3"),
+			),
+		},
+		{
+			[]string{"COL.1"},
+			[]bool{false, false, true, true, true},
+			New(
+				series.New([]string{"b", "a"}, series.String, "COL.1"),
+				series.New([]int{1, 2}, series.Int, "COL.2"),
+				series.New([]float64{3.0, 4.0}, series.Float, "COL.3"),
+			),
+		},
+	}
+
+	for i, tc := range table {
+		dups := a.Duplicated(tc.subset...)
+		if err := dups.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+		}
+		received := make([]bool, dups.Len())
+		for j := range received {
+			received[j] = dups.Val(j)
+		}
+		if !reflect.DeepEqual(tc.expDup, received) {
+			t.Errorf("Test: %d\nDifferent duplicated:\nA:%v\nB:%v", i, tc.expDup, received)
+		}
+
+		b := a.Unique(tc.subset...)
+		if err := b.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+		}
+		// Check that the types are the same between both DataFrames
+		if !reflect.DeepEqual(tc.expDf.Types(), b.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, tc.expDf.Types(), b.Types())
+		}
+		// Check that the values are the same between both DataFrames
+		if !reflect.DeepEqual(tc.expDf.Records(), b.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expDf.Records(), b.Records())
+		}
+	}
+
+	if b := a.Unique("COL.4"); b.Error() == nil {
+		t.Errorf("Expected error for unknown column name")
+	}
+}
+
+func TestDataFrame_ArrangeNumeric(t *testing.T) {
+	a := New(
+		series.New([]string{"10", "2", "x", "1.5", "100", "b"}, series.String, "A"),
+		series.New([]int{1, 2, 3, 4, 5, 6}, series.Int, "B"),
+	)
+	table := []struct {
+		colnames []Order
+		expDf    DataFrame
+	}{
+		{
+			[]Order{Sort("A")},
+			New(
+				series.New([]string{"1.5", "10", "100", "2", "b", "x"}, series.String, "A"),
+				series.New([]int{4, 1, 5, 2, 6, 3}, series.Int, "B"),
+			),
+		},
+		{
+			[]Order{NumSort("A")},
+			New(
+				series.New([]string{"1.5", "2", "10", "100", "b", "x"}, series.String, "A"),
+				series.New([]int{4, 2, 1, 5, 6, 3}, series.Int, "B"),
+			),
+		},
+		{
+			[]Order{RevNumSort("A")},
+			New(
+				series.New([]string{"100", "10", "2", "1.5", "x", "b"}, series.String, "A"),
+				series.New([]int{5, 1, 2, 4, 3, 6}, series.Int, "B"),
+			),
+		},
+	}
+	for i, tc := range table {
+		b := a.Arrange(tc.colnames...)
+
+		if err := b.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+		}
+		if !reflect.DeepEqual(tc.expDf.Types(), b.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, tc.expDf.Types(), b.Types())
+		}
+		if !reflect.DeepEqual(tc.expDf.Names(), b.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expDf.Names(), b.Names())
+		}
+		if !reflect.DeepEqual(tc.expDf.Records(), b.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expDf.Records(), b.Records())
+		}
+	}
+}
+
+func TestGroups_AggregationSumMean(t *testing.T) {
+	a := New(
+		series.New([]string{"b", "a", "b", "a", "b"}, series.String, "key"),
+		series.New([]int{1, 2, 3, 4, 5}, series.Int, "x"),
+		series.New([]float64{1.5, 2.0, 2.5, 4.0, 5.0}, series.Float, "y"),
+	)
+	groups := a.GroupBy("key")
+	b := groups.Aggregation(
+		[]AggregationType{Aggregation_SUM, Aggregation_MEAN, Aggregation_SUM, Aggregation_MEAN},
+		[]string{"x", "x", "y", "y"},
+	)
+	expDf := New(
+		series.New([]string{"a", "b"}, series.String, "key"),
+		series.New([]float64{6, 9}, series.Float, "x_SUM"),
+		series.New([]float64{3, 3}, series.Float, "x_MEAN"),
+		series.New([]float64{6, 9}, series.Float, "y_SUM"),
+		series.New([]float64{3, 3}, series.Float, "y_MEAN"),
+	)
+
+	if err := b.Error(); err != nil {
+		t.Errorf("Error:%v", err)
+	}
+	if !reflect.DeepEqual(expDf.Types(), b.Types()) {
+		t.Errorf("Different types:\nA:%v\nB:%v", expDf.Types(), b.Types())
+	}
+	if !reflect.DeepEqual(expDf.Names(), b.Names()) {
+		t.Errorf("Different colnames:\nA:%v\nB:%v", expDf.Names(), b.Names())
+	}
+	if !reflect.DeepEqual(expDf.Records(), b.Records()) {
+		t.Errorf("Different values:\nA:%v\nB:%v", expDf.Records(), b.Records())
+	}
+
+	b = groups.Aggregation([]AggregationType{Aggregation_SUM}, []string{"x", "y"})
+	if b.Error() == nil {
+		t.Errorf("Expected error due to mismatched lengths")
+	}
+}
+
+func TestGroups_AggregationMulti(t *testing.T) {
+	a := New(
+		series.New([]string{"b", "a", "b", "a", "b"}, series.String, "key"),
+		series.New([]float64{1.0, 2.0, 3.0, 4.0, 5.0}, series.Float, "price"),
+		series.New([]int{1, 1, 2, 2, 3}, series.Int, "amount"),
+	)
+	b := a.GroupBy("key").AggregationMulti(map[string][]AggregationType{
+		"price":  {Aggregation_MEAN, Aggregation_MAX},
+		"amount": {Aggregation_SUM},
+	})
+	expDf := New(
+		series.New([]string{"a", "b"}, series.String, "key"),
+		series.New([]float64{3, 6}, series.Float, "amount_SUM"),
+		series.New([]float64{3, 3}, series.Float, "price_MEAN"),
+		series.New([]float64{4, 5}, series.Float, "price_MAX"),
+	)
+
+	if err := b.Error(); err != nil {
+		t.Errorf("Error:%v", err)
+	}
+	if !reflect.DeepEqual(expDf.Types(), b.Types()) {
+		t.Errorf("Different types:\nA:%v\nB:%v", expDf.Types(), b.Types())
+	}
+	if !reflect.DeepEqual(expDf.Names(), b.Names()) {
+		t.Errorf("Different colnames:\nA:%v\nB:%v", expDf.Names(), b.Names())
+	}
+	if !reflect.DeepEqual(expDf.Records(), b.Records()) {
+		t.Errorf("Different values:\nA:%v\nB:%v", expDf.Records(), b.Records())
+	}
+}
+
+func TestGroups_Count(t *testing.T) {
+	a := New(
+		series.New([]string{"b", "a", "b", "a", "b", "b"}, series.String, "key1"),
+		series.New([]int{1, 2, 1, 2, 2, 1}, series.Int, "key2"),
+		series.New([]float64{3.0, 4.0, 5.3, 3.2, 1.2, 0.5}, series.Float, "values"),
+	)
+	table := []struct {
+		keys  []string
+		expDf DataFrame
+	}{
+		{
+			[]string{"key1"},
+			New(
+				series.New([]string{"a", "b"}, series.String, "key1"),
+				series.New([]int{2, 4}, series.Int, "count"),
+			),
+		},
+		{
+			[]string{"key1", "key2"},
+			New(
+				series.New([]string{"a", "b", "b"}, series.String, "key1"),
+				series.New([]int{2, 1, 2}, series.Int, "key2"),
+				series.New([]int{2, 3, 1}, series.Int, "count"),
+			),
+		},
+	}
+	for i, tc := range table {
+		b := a.GroupBy(tc.keys...).Count()
+
+		if err := b.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+		}
+		if !reflect.DeepEqual(tc.expDf.Types(), b.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, tc.expDf.Types(), b.Types())
+		}
+		if !reflect.DeepEqual(tc.expDf.Names(), b.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expDf.Names(), b.Names())
+		}
+		if !reflect.DeepEqual(tc.expDf.Records(), b.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expDf.Records(), b.Records())
+		}
+		if total := b.Col("count").Sum(); total != float64(a.NRow()) {
+			t.Errorf("Test: %d\nExpected counts to sum %d, got %v", i, a.NRow(), total)
+		}
+	}
+}
+
+func TestReadCSVStream(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString("id,name,value\n")
+	nrows := 10007
+	for i := 0; i < nrows; i++ {
+		fmt.Fprintf(&sb, "%d,name%d,%d.5\n", i, i%7, i)
+	}
+	csvStr := sb.String()
+
+	total, batches := 0, 0
+	err := ReadCSVStream(strings.NewReader(csvStr), 1000, func(df GotaDataFrame) error {
+		if err := df.Error(); err != nil {
+			return err
+		}
+		expTypes := []series.Type{series.Int, series.String, series.Float}
+		if !reflect.DeepEqual(expTypes, df.Types()) {
+			t.Errorf("Batch: %d\nDifferent types:\nA:%v\nB:%v", batches, expTypes, df.Types())
+		}
+		expNames := []string{"id", "name", "value"}
+		if !reflect.DeepEqual(expNames, df.Names()) {
+			t.Errorf("Batch: %d\nDifferent colnames:\nA:%v\nB:%v", batches, expNames, df.Names())
+		}
+		if first := df.Elem(0, 0).String(); first != strconv.Itoa(batches*1000) {
+			t.Errorf("Batch: %d\nExpected first id %d, got %s", batches, batches*1000, first)
+		}
+		total += df.NRow()
+		batches++
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Error:%v", err)
+	}
+	if total != nrows {
+		t.Errorf("Expected %d rows, got %d", nrows, total)
+	}
+	if batches != 11 {
+		t.Errorf("Expected 11 batches, got %d", batches)
+	}
+
+	abort := fmt.Errorf("abort")
+	batches = 0
+	err = ReadCSVStream(strings.NewReader(csvStr), 1000, func(df GotaDataFrame) error {
+		batches++
+		if batches == 2 {
+			return abort
+		}
+		return nil
+	})
+	if err != abort {
+		t.Errorf("Expected error %v, got %v", abort, err)
+	}
+	if batches != 2 {
+		t.Errorf("Expected reading to stop after 2 batches, got %d", batches)
+	}
+
+	err = ReadCSVStream(strings.NewReader(csvStr), 0, func(df GotaDataFrame) error { return nil })
+	if err == nil {
+		t.Errorf("Expected error due to non positive batch size")
+	}
+}
+
+func TestDataFrame_WriteHTML(t *testing.T) {
+	a := LoadRecords(
+		[][]string{
+			{"A<B", "COL.2"},
+			{"x & y", "1"},
+			{"<b>bold</b>", "NaN"},
+		},
+	)
+	table := []struct {
+		options  []WriteOption
+		expected string
+	}{
+		{
+			nil,
+			`<table>
+  <thead>
+    <tr><th>A&lt;B</th><th>COL.2</th></tr>
+  </thead>
+  <tbody>
+    <tr><td>x &amp; y</td><td>1</td></tr>
+    <tr><td>&lt;b&gt;bold&lt;/b&gt;</td><td>NaN</td></tr>
+  </tbody>
+</table>
+`,
+		},
+		{
+			[]WriteOption{WithHTMLID("data"), WithHTMLClass(`wide "table"`), WriteHeader(false)},
+			`<table id="data" class="wide &#34;table&#34;">
+  <tbody>
+    <tr><td>x &amp; y</td><td>1</td></tr>
+    <tr><td>&lt;b&gt;bold&lt;/b&gt;</td><td>NaN</td></tr>
+  </tbody>
+</table>
+`,
+		},
+	}
+	for i, tc := range table {
+		buf := new(bytes.Buffer)
+		if err := a.WriteHTML(buf, tc.options...); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+		}
+		if tc.expected != buf.String() {
+			t.Errorf("Test: %d\nexpected: %v\nreceived: %v", i, tc.expected, buf.String())
+		}
+	}
+}
+
+func TestDataFrame_RenameAll(t *testing.T) {
+	a := New(
+		series.New([]string{"b", "a"}, series.String, "COL.1"),
+		series.New([]int{1, 2}, series.Int, "COL.2"),
+		series.New([]float64{3.0, 4.0}, series.Float, "COL.3"),
+	)
+	table := []struct {
+		mapping  map[string]string
+		expNames []string
+		err      bool
+	}{
+		{
+			map[string]string{"COL.1": "name", "COL.3": "score"},
+			[]string{"name", "COL.2", "score"},
+			false,
+		},
+		{
+			map[string]string{"COL.1": "COL.2", "COL.2": "COL.1"},
+			[]string{"COL.2", "COL.1", "COL.3"},
+			false,
+		},
+		{
+			map[string]string{"COL.1": "name", "COL.9": "other"},
+			nil,
+			true,
+		},
+		{
+			map[string]string{"COL.1": "COL.3"},
+			nil,
+			true,
+		},
+	}
+	for i, tc := range table {
+		b := a.RenameAll(tc.mapping)
+
+		if tc.err {
+			if b.Error() == nil {
+				t.Errorf("Test: %d\nExpected error", i)
+			}
+			continue
+		}
+		if err := b.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+		}
+		if !reflect.DeepEqual(tc.expNames, b.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expNames, b.Names())
+		}
+		if !reflect.DeepEqual(a.Records()[1:], b.Records()[1:]) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, a.Records(), b.Records())
+		}
+	}
+	if !reflect.DeepEqual([]string{"COL.1", "COL.2", "COL.3"}, a.Names()) {
+		t.Errorf("Original DataFrame was modified: %v", a.Names())
+	}
+}
+
+func TestDataFrame_InsertColumn(t *testing.T) {
+	a := New(
+		series.New([]string{"b", "a"}, series.String, "COL.1"),
+		series.New([]int{1, 2}, series.Int, "COL.2"),
+	)
+	s := series.New([]float64{3.0, 4.0}, series.Float, "NEW")
+	table := []struct {
+		pos   int
+		s     series.Series1
+		expDf DataFrame
+	}{
+		{
+			0,
+			s,
+			New(
+				series.New([]float64{3.0, 4.0}, series.Float, "NEW"),
+				series.New([]string{"b", "a"}, series.String, "COL.1"),
+				series.New([]int{1, 2}, series.Int, "COL.2"),
+			),
+		},
+		{
+			1,
+			s,
+			New(
+				series.New([]string{"b", "a"}, series.String, "COL.1"),
+				series.New([]float64{3.0, 4.0}, series.Float, "NEW"),
+				series.New([]int{1, 2}, series.Int, "COL.2"),
+			),
+		},
+		{
+			2,
+			s,
+			New(
+				series.New([]string{"b", "a"}, series.String, "COL.1"),
+				series.New([]int{1, 2}, series.Int, "COL.2"),
+				series.New([]float64{3.0, 4.0}, series.Float, "NEW"),
+			),
+		},
+		{-1, s, nil},
+		{3, s, nil},
+		{0, series.New([]float64{3.0}, series.Float, "NEW"), nil},
+		{0, series.New([]float64{3.0, 4.0}, series.Float, "COL.2"), nil},
+	}
+	for i, tc := range table {
+		b := a.InsertColumn(tc.pos, tc.s)
+
+		if tc.expDf == nil {
+			if b.Error() == nil {
+				t.Errorf("Test: %d\nExpected error", i)
+			}
+			continue
+		}
+		if err := b.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+		}
+		if !reflect.DeepEqual(tc.expDf.Types(), b.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, tc.expDf.Types(), b.Types())
+		}
+		if !reflect.DeepEqual(tc.expDf.Names(), b.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expDf.Names(), b.Names())
+		}
+		if !reflect.DeepEqual(tc.expDf.Records(), b.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expDf.Records(), b.Records())
+		}
+	}
+}
+
+func TestDataFrame_DescribeBool(t *testing.T) {
+	a := New(
+		series.New([]interface{}{true, false, true, nil, true}, series.Bool, "B"),
+		series.New([]int{1, 2, 3, 4, 5}, series.Int, "I"),
+	)
+	b := a.Describe()
+
+	if err := b.Error(); err != nil {
+		t.Errorf("Error:%v", err)
+	}
+	expLabels := []string{"mean", "median", "stddev", "min", "25%", "50%", "75%", "max", "true", "false"}
+	if received := b.Col("column").Records(); !reflect.DeepEqual(expLabels, received) {
+		t.Errorf("Different labels:\nA:%v\nB:%v", expLabels, received)
+	}
+	expBool := []string{"0.750000", "-", "-", "-", "-", "-", "-", "-", "3", "1"}
+	if received := b.Col("B").Records(); !reflect.DeepEqual(expBool, received) {
+		t.Errorf("Different values:\nA:%v\nB:%v", expBool, received)
+	}
+	if received := b.Col("I").Records(); received[0] != "3.000000" || received[8] != "NaN" || received[9] != "NaN" {
+		t.Errorf("Unexpected numeric summary: %v", received)
+	}
+	expTypes := []series.Type{series.String, series.String, series.Float}
+	if !reflect.DeepEqual(expTypes, b.Types()) {
+		t.Errorf("Different types:\nA:%v\nB:%v", expTypes, b.Types())
+	}
+}
+
+func TestDataFrame_DescribeQuantiles(t *testing.T) {
+	values := make([]float64, 101)
+	for i := range values {
+		values[i] = float64(i)
+	}
+	a := New(
+		series.New(values, series.Float, "latency"),
+	)
+	b := a.DescribeQuantiles(0.1, 0.9, 0.99)
+	expDf := New(
+		series.New([]string{"mean", "median", "stddev", "min", "10%", "90%", "99%", "max"}, series.String, "column"),
+		series.New([]float64{
+			a.Col("latency").Mean(),
+			a.Col("latency").Median(),
+			a.Col("latency").StdDev(),
+			0, 10, 90, 99, 100,
+		}, series.Float, "latency"),
+	)
+
+	if err := b.Error(); err != nil {
+		t.Errorf("Error:%v", err)
+	}
+	if !reflect.DeepEqual(expDf.Types(), b.Types()) {
+		t.Errorf("Different types:\nA:%v\nB:%v", expDf.Types(), b.Types())
+	}
+	if !reflect.DeepEqual(expDf.Names(), b.Names()) {
+		t.Errorf("Different colnames:\nA:%v\nB:%v", expDf.Names(), b.Names())
+	}
+	if !reflect.DeepEqual(expDf.Records(), b.Records()) {
+		t.Errorf("Different values:\nA:%v\nB:%v", expDf.Records(), b.Records())
+	}
+
+	for _, q := range []float64{-0.1, 1.5} {
+		if err := a.DescribeQuantiles(0.5, q).Error(); err == nil {
+			t.Errorf("Expected error due to quantile %v out of range", q)
+		}
+	}
+}
+
+func TestDataFrame_Corr(t *testing.T) {
+	a := New(
+		series.New([]float64{1, 2, 3, 4, 5}, series.Float, "x"),
+		series.New([]int{2, 4, 6, 8, 10}, series.Int, "double"),
+		series.New([]float64{5, 4, 3, 2, 1}, series.Float, "reverse"),
+		series.New([]string{"a", "b", "c", "d", "e"}, series.String, "label"),
+		series.New([]interface{}{1.0, nil, 2.0, 1.0, 3.0}, series.Float, "nan"),
+	)
+	b := a.Corr()
+
+	if err := b.Error(); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	expNames := []string{"column", "x", "double", "reverse", "nan"}
+	if !reflect.DeepEqual(expNames, b.Names()) {
+		t.Errorf("Different colnames:\nA:%v\nB:%v", expNames, b.Names())
+	}
+	if received := b.Col("column").Records(); !reflect.DeepEqual(expNames[1:], received) {
+		t.Errorf("Different labels:\nA:%v\nB:%v", expNames[1:], received)
+	}
+	for i, name := range expNames[1:] {
+		if v := b.Col(name).Elem(i).Float(); !compareFloats(v, 1.0, 6) {
+			t.Errorf("Expected diagonal of %s to be 1, got %v", name, v)
+		}
+	}
+	if v := b.Col("double").Elem(0).Float(); !compareFloats(v, 1.0, 6) {
+		t.Errorf("Expected correlation of x and double to be 1, got %v", v)
+	}
+	if v := b.Col("reverse").Elem(0).Float(); !compareFloats(v, -1.0, 6) {
+		t.Errorf("Expected correlation of x and reverse to be -1, got %v", v)
+	}
+	// Pairwise complete observations of x and nan: (1, 1), (3, 2), (4, 1), (5, 3)
+	expected := 3.25 / math.Sqrt(8.75*2.75)
+	if v := b.Col("nan").Elem(0).Float(); !compareFloats(v, expected, 6) {
+		t.Errorf("Expected correlation of x and nan to be %v, got %v", expected, v)
+	}
+
+	c := New(series.New([]string{"a"}, series.String, "label"))
+	if err := c.Corr().Error(); err == nil {
+		t.Errorf("Expected error due to no numeric columns")
+	}
+}
+
+func TestDataFrame_Cov(t *testing.T) {
+	a := New(
+		series.New([]float64{1, 2, 6}, series.Float, "x"),
+		series.New([]int{3, 1, 2}, series.Int, "y"),
+		series.New([]string{"a", "b", "c"}, series.String, "label"),
+	)
+	b := a.Cov()
+
+	if err := b.Error(); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	// x: mean 3, deviations -2, -1, 3
+	// y: mean 2, deviations 1, -1, 0
+	expected := [][]string{
+		{"column", "x", "y"},
+		{"x", "7.000000", "-0.500000"},
+		{"y", "-0.500000", "1.000000"},
+	}
+	if received := b.Records(); !reflect.DeepEqual(expected, received) {
+		t.Errorf("Different records:\nA:%v\nB:%v", expected, received)
+	}
+
+	c := New(series.New([]string{"a"}, series.String, "label"))
+	if err := c.Cov().Error(); err == nil {
+		t.Errorf("Expected error due to no numeric columns")
+	}
+}
+
+func TestDataFrame_RowNumber(t *testing.T) {
+	a := New(
+		series.New([]string{"b", "a", "c"}, series.String, "COL.1"),
+		series.New([]int{1, 2, 3}, series.Int, "COL.2"),
+	)
+	table := []struct {
+		df    DataFrame
+		expDf DataFrame
+	}{
+		{
+			a.RowNumber("row"),
+			New(
+				series.New([]string{"b", "a", "c"}, series.String, "COL.1"),
+				series.New([]int{1, 2, 3}, series.Int, "COL.2"),
+				series.New([]int{0, 1, 2}, series.Int, "row"),
+			),
+		},
+		{
+			a.RowNumber("row", true),
+			New(
+				series.New([]string{"b", "a", "c"}, series.String, "COL.1"),
+				series.New([]int{1, 2, 3}, series.Int, "COL.2"),
+				series.New([]int{1, 2, 3}, series.Int, "row"),
+			),
+		},
+		{a.RowNumber("COL.2"), nil},
+	}
+	for i, tc := range table {
+		b := tc.df
+
+		if tc.expDf == nil {
+			if b.Error() == nil {
+				t.Errorf("Test: %d\nExpected error", i)
+			}
+			continue
+		}
+		if err := b.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+		}
+		if !reflect.DeepEqual(tc.expDf.Types(), b.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, tc.expDf.Types(), b.Types())
+		}
+		if !reflect.DeepEqual(tc.expDf.Names(), b.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expDf.Names(), b.Names())
+		}
+		if !reflect.DeepEqual(tc.expDf.Records(), b.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expDf.Records(), b.Records())
+		}
+	}
+}
+
+func TestDataFrame_CApplyParallel(t *testing.T) {
+	a := LoadRecords(
+		[][]string{
+			{"A", "B", "C", "D", "E"},
+			{"a", "4", "5.1", "true", "1"},
+			{"b", "4", "6.0", "true", "2"},
+			{"c", "3", "6.0", "false", "3"},
+			{"a", "2", "7.1", "false", "4"},
+		},
+	)
+	mean := func(s series.Series1) series.Series1 {
+		floats := s.Float()
+		sum := 0.0
+		for _, f := range floats {
+			sum += f
+		}
+		return series.Floats(sum / float64(len(floats)))
+	}
+	double := func(s series.Series1) series.Series1 {
+		floats := s.Float()
+		for i := range floats {
+			floats[i] *= 2
+		}
+		return series.Floats(floats)
+	}
+	for _, f := range []func(series.Series1) series.Series1{mean, double} {
+		expDf := a.CApply(f)
+		for _, workers := range []int{1, 3, 10, 0} {
+			b := a.CApplyParallel(f, workers)
+
+			if err := b.Error(); err != nil {
+				t.Errorf("Workers: %d\nError:%v", workers, err)
+			}
+			if !reflect.DeepEqual(expDf.Types(), b.Types()) {
+				t.Errorf("Workers: %d\nDifferent types:\nA:%v\nB:%v", workers, expDf.Types(), b.Types())
+			}
+			if !reflect.DeepEqual(expDf.Names(), b.Names()) {
+				t.Errorf("Workers: %d\nDifferent colnames:\nA:%v\nB:%v", workers, expDf.Names(), b.Names())
+			}
+			if !reflect.DeepEqual(expDf.Records(), b.Records()) {
+				t.Errorf("Workers: %d\nDifferent values:\nA:%v\nB:%v", workers, expDf.Records(), b.Records())
+			}
+		}
+	}
+
+	failing := func(s series.Series1) series.Series1 {
+		ret := s.Copy()
+		if s.Name == "C" || s.Name == "E" {
+			ret.Err = fmt.Errorf("cannot process %s", s.Name)
+		}
+		return ret
+	}
+	b := a.CApplyParallel(failing, 4)
+	if b.Error() == nil {
+		t.Fatalf("Expected error")
+	}
+	if !strings.Contains(b.Error().Error(), "cannot process C") {
+		t.Errorf("Expected error of the first failing column, got: %v", b.Error())
+	}
+}
+
+func TestDataFrame_ElemSafe(t *testing.T) {
+	a := New(
+		series.New([]string{"b", "a"}, series.String, "COL.1"),
+		series.New([]int{1, 2}, series.Int, "COL.2"),
+	)
+	table := []struct {
+		r, c     int
+		expected string
+		err      bool
+	}{
+		{0, 0, "b", false},
+		{1, 1, "2", false},
+		{-1, 0, "", true},
+		{2, 0, "", true},
+		{0, -1, "", true},
+		{0, 2, "", true},
+	}
+	for i, tc := range table {
+		e, err := a.ElemSafe(tc.r, tc.c)
+		if tc.err {
+			if err == nil {
+				t.Errorf("Test: %d\nExpected error", i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+			continue
+		}
+		if e.String() != tc.expected {
+			t.Errorf("Test: %d\nExpected:%v\nReceived:%v", i, tc.expected, e.String())
+		}
+	}
+}
+
+func TestDataFrame_At(t *testing.T) {
+	a := New(
+		series.New([]string{"b", "a"}, series.String, "COL.1"),
+		series.New([]int{1, 2}, series.Int, "COL.2"),
+	)
+	e, err := a.At(1, "COL.2")
+	if err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	if e.String() != "2" {
+		t.Errorf("Expected:2\nReceived:%v", e.String())
+	}
+	if _, err := a.At(0, "COL.3"); err == nil {
+		t.Errorf("Expected error due to unknown column name")
+	}
+	for _, row := range []int{-1, 2} {
+		if _, err := a.At(row, "COL.1"); err == nil {
+			t.Errorf("Row: %d\nExpected error due to row out of range", row)
+		}
+	}
+}
+
+func TestDataFrame_SetAt(t *testing.T) {
+	a := New(
+		series.New([]string{"b", "a"}, series.String, "COL.1"),
+		series.New([]int{1, 2}, series.Int, "COL.2"),
+	)
+	table := []struct {
+		row     int
+		colname string
+		value   interface{}
+		expDf   DataFrame
+	}{
+		{
+			1,
+			"COL.2",
+			"5",
+			New(
+				series.New([]string{"b", "a"}, series.String, "COL.1"),
+				series.New([]int{1, 5}, series.Int, "COL.2"),
+			),
+		},
+		{
+			0,
+			"COL.1",
+			3,
+			New(
+				series.New([]string{"3", "a"}, series.String, "COL.1"),
+				series.New([]int{1, 2}, series.Int, "COL.2"),
+			),
+		},
+		{
+			0,
+			"COL.2",
+			nil,
+			New(
+				series.New([]string{"b", "a"}, series.String, "COL.1"),
+				series.New([]interface{}{nil, 2}, series.Int, "COL.2"),
+			),
+		},
+		{
+			1,
+			"COL.2",
+			math.NaN(),
+			New(
+				series.New([]string{"b", "a"}, series.String, "COL.1"),
+				series.New([]interface{}{1, nil}, series.Int, "COL.2"),
+			),
+		},
+		{0, "COL.2", "abc", nil},
+		{0, "COL.3", 3, nil},
+		{-1, "COL.1", "c", nil},
+		{2, "COL.1", "c", nil},
+	}
+	for i, tc := range table {
+		b := a.SetAt(tc.row, tc.colname, tc.value)
+
+		if tc.expDf == nil {
+			if b.Error() == nil {
+				t.Errorf("Test: %d\nExpected error", i)
+			}
+			continue
+		}
+		if err := b.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+		}
+		if !reflect.DeepEqual(tc.expDf.Types(), b.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, tc.expDf.Types(), b.Types())
+		}
+		if !reflect.DeepEqual(tc.expDf.Records(), b.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expDf.Records(), b.Records())
+		}
+	}
+	// The original DataFrame is left unchanged
+	expected := [][]string{{"COL.1", "COL.2"}, {"b", "1"}, {"a", "2"}}
+	if !reflect.DeepEqual(expected, a.Records()) {
+		t.Errorf("Original DataFrame modified:\nA:%v\nB:%v", expected, a.Records())
+	}
+}
+
+func TestDataFrame_Rows(t *testing.T) {
+	a := New(
+		series.New([]string{"b", "a", "c"}, series.String, "COL.1"),
+		series.New([]int{1, 2, 3}, series.Int, "COL.2"),
+		series.New([]float64{3.0, 4.0, 5.0}, series.Float, "COL.3"),
+	)
+	maps := a.Maps()
+
+	n := 0
+	for i, row := range a.Rows() {
+		if i != n {
+			t.Errorf("Expected row index %d, got %d", n, i)
+		}
+		if !reflect.DeepEqual(maps[i], row) {
+			t.Errorf("Row: %d\nDifferent values:\nA:%v\nB:%v", i, maps[i], row)
+		}
+		n++
+	}
+	if n != a.NRow() {
+		t.Errorf("Expected %d rows, iterated %d", a.NRow(), n)
+	}
+
+	n = 0
+	for _, row := range a.Rows() {
+		n++
+		if row["COL.1"] == "a" {
+			break
+		}
+	}
+	if n != 2 {
+		t.Errorf("Expected to stop after 2 rows, iterated %d", n)
+	}
+
+	for range (GotaDataFrame{Err: fmt.Errorf("error")}).Rows() {
+		t.Errorf("Expected no rows on a DataFrame with errors")
+	}
+}
+
+func TestDataFrame_Reverse(t *testing.T) {
+	a := New(
+		series.New([]string{"b", "a", "c"}, series.String, "COL.1"),
+		series.New([]interface{}{1, nil, 3}, series.Int, "COL.2"),
+		series.New([]float64{3.0, 4.0, 5.0}, series.Float, "COL.3"),
+	)
+	b := a.Reverse()
+	expDf := New(
+		series.New([]string{"c", "a", "b"}, series.String, "COL.1"),
+		series.New([]interface{}{3, nil, 1}, series.Int, "COL.2"),
+		series.New([]float64{5.0, 4.0, 3.0}, series.Float, "COL.3"),
+	)
+
+	if err := b.Error(); err != nil {
+		t.Errorf("Error:%v", err)
+	}
+	if !reflect.DeepEqual(expDf.Types(), b.Types()) {
+		t.Errorf("Different types:\nA:%v\nB:%v", expDf.Types(), b.Types())
+	}
+	if !reflect.DeepEqual(expDf.Names(), b.Names()) {
+		t.Errorf("Different colnames:\nA:%v\nB:%v", expDf.Names(), b.Names())
+	}
+	if !reflect.DeepEqual(expDf.Records(), b.Records()) {
+		t.Errorf("Different values:\nA:%v\nB:%v", expDf.Records(), b.Records())
+	}
+}
+
+func TestDataFrame_FilterByFunc(t *testing.T) {
+	a := New(
+		series.New([]string{"x", "y", "x", "x"}, series.String, "COL.1"),
+		series.New([]int{5, 4, 1, 7}, series.Int, "COL.2"),
+		series.New([]float64{3.0, 1.0, 2.0, 6.0}, series.Float, "COL.3"),
+	)
+	table := []struct {
+		f     func(row map[string]interface{}) bool
+		expDf DataFrame
+	}{
+		{
+			func(row map[string]interface{}) bool {
+				return float64(row["COL.2"].(int)) > row["COL.3"].(float64) && row["COL.1"] == "x"
+			},
+			New(
+				series.New([]string{"x", "x"}, series.String, "COL.1"),
+				series.New([]int{5, 7}, series.Int, "COL.2"),
+				series.New([]float64{3.0, 6.0}, series.Float, "COL.3"),
+			),
+		},
+		{
+			func(row map[string]interface{}) bool {
+				return false
+			},
+			New(
+				series.New([]string{}, series.String, "COL.1"),
+				series.New([]int{}, series.Int, "COL.2"),
+				series.New([]float64{}, series.Float, "COL.3"),
+			),
+		},
+	}
+	for i, tc := range table {
+		b := a.FilterByFunc(tc.f)
+
+		if err := b.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+		}
+		if !reflect.DeepEqual(tc.expDf.Types(), b.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, tc.expDf.Types(), b.Types())
+		}
+		if !reflect.DeepEqual(tc.expDf.Names(), b.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expDf.Names(), b.Names())
+		}
+		if !reflect.DeepEqual(tc.expDf.Records(), b.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expDf.Records(), b.Records())
+		}
+	}
+}
+
+func TestDataFrame_Filter_AndNotXor(t *testing.T) {
+	a := New(
+		series.New([]string{"b", "a", "b", "c", "d"}, series.String, "COL.1"),
+		series.New([]int{1, 2, 4, 5, 4}, series.Int, "COL.2"),
+		series.New([]float64{3.0, 4.0, 5.3, 3.2, 1.2}, series.Float, "COL.3"),
+	)
+	filters := []F{
+		{Colname: "COL.2", Comparator: series.GreaterEq, Comparando: 2},
+		{Colname: "COL.3", Comparator: series.Greater, Comparando: 2.5},
+	}
+	table := []struct {
+		agg   Aggregation
+		expDf GotaDataFrame
+	}{
+		{
+			AndNot,
+			New(
+				series.New([]string{"d"}, series.String, "COL.1"),
+				series.New([]int{4}, series.Int, "COL.2"),
+				series.New([]float64{1.2}, series.Float, "COL.3"),
+			),
+		},
+		{
+			Xor,
+			New(
+				series.New([]string{"b", "d"}, series.String, "COL.1"),
+				series.New([]int{1, 4}, series.Int, "COL.2"),
+				series.New([]float64{3.0, 1.2}, series.Float, "COL.3"),
+			),
+		},
+	}
+	for i, tc := range table {
+		b := a.FilterAggregation(tc.agg, filters...)
+
+		if err := b.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+		}
+		if !reflect.DeepEqual(tc.expDf.Types(), b.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, tc.expDf.Types(), b.Types())
+		}
+		if !reflect.DeepEqual(tc.expDf.Names(), b.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expDf.Names(), b.Names())
+		}
+		if !reflect.DeepEqual(tc.expDf.Records(), b.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expDf.Records(), b.Records())
+		}
+	}
+
+	if err := a.FilterAggregation(Aggregation(42), filters...).Error(); err == nil {
+		t.Errorf("Expected error due to unknown aggregation")
+	}
+}
+
+func TestDataFrame_Filter_Between(t *testing.T) {
+	a := New(
+		series.New([]string{"b", "a", "b", "c", "d"}, series.String, "COL.1"),
+		series.New([]int{1, 2, 4, 5, 3}, series.Int, "COL.2"),
+		series.New([]float64{3.0, 4.0, 5.3, 3.2, 1.2}, series.Float, "COL.3"),
+	)
+	table := []struct {
+		filters []F
+		expDf   GotaDataFrame
+	}{
+		{
+			Between("COL.2", 2, 4),
+			New(
+				series.New([]string{"a", "b", "d"}, series.String, "COL.1"),
+				series.New([]int{2, 4, 3}, series.Int, "COL.2"),
+				series.New([]float64{4.0, 5.3, 1.2}, series.Float, "COL.3"),
+			),
+		},
+		{
+			Between("COL.2", 2, 4, Exclusive(true, false)),
+			New(
+				series.New([]string{"b", "d"}, series.String, "COL.1"),
+				series.New([]int{4, 3}, series.Int, "COL.2"),
+				series.New([]float64{5.3, 1.2}, series.Float, "COL.3"),
+			),
+		},
+		{
+			Between("COL.3", 3.0, 4.0),
+			New(
+				series.New([]string{"b", "a", "c"}, series.String, "COL.1"),
+				series.New([]int{1, 2, 5}, series.Int, "COL.2"),
+				series.New([]float64{3.0, 4.0, 3.2}, series.Float, "COL.3"),
+			),
+		},
+		{
+			Between("COL.3", 3.0, 4.0, Exclusive(true, true)),
+			New(
+				series.New([]string{"c"}, series.String, "COL.1"),
+				series.New([]int{5}, series.Int, "COL.2"),
+				series.New([]float64{3.2}, series.Float, "COL.3"),
+			),
+		},
+	}
+	for i, tc := range table {
+		b := a.FilterAggregation(And, tc.filters...)
+
+		if err := b.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+		}
+		if !reflect.DeepEqual(tc.expDf.Types(), b.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, tc.expDf.Types(), b.Types())
+		}
+		if !reflect.DeepEqual(tc.expDf.Names(), b.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expDf.Names(), b.Names())
+		}
+		if !reflect.DeepEqual(tc.expDf.Records(), b.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expDf.Records(), b.Records())
+		}
+	}
+}
+
+func TestDataFrame_Slice(t *testing.T) {
+	a := New(
+		series.New([]string{"b", "a", "c", "d"}, series.String, "COL.1"),
+		series.New([]int{1, 2, 3, 4}, series.Int, "COL.2"),
+	)
+	table := []struct {
+		start, end int
+		expDf      DataFrame
+	}{
+		{
+			1,
+			3,
+			New(
+				series.New([]string{"a", "c"}, series.String, "COL.1"),
+				series.New([]int{2, 3}, series.Int, "COL.2"),
+			),
+		},
+		{
+			0,
+			4,
+			New(
+				series.New([]string{"b", "a", "c", "d"}, series.String, "COL.1"),
+				series.New([]int{1, 2, 3, 4}, series.Int, "COL.2"),
+			),
+		},
+		{
+			2,
+			2,
+			New(
+				series.New([]string{}, series.String, "COL.1"),
+				series.New([]int{}, series.Int, "COL.2"),
+			),
+		},
+		{-1, 2, nil},
+		{2, 5, nil},
+		{3, 1, nil},
+	}
+	for i, tc := range table {
+		b := a.Slice(tc.start, tc.end)
+
+		if tc.expDf == nil {
+			if b.Error() == nil {
+				t.Errorf("Test: %d\nExpected error", i)
+			}
+			continue
+		}
+		if err := b.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+		}
+		if !reflect.DeepEqual(tc.expDf.Types(), b.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, tc.expDf.Types(), b.Types())
+		}
+		if !reflect.DeepEqual(tc.expDf.Names(), b.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expDf.Names(), b.Names())
+		}
+		if !reflect.DeepEqual(tc.expDf.Records(), b.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expDf.Records(), b.Records())
+		}
+	}
+}
+
+func TestLoadRecords_Time(t *testing.T) {
+	a := LoadRecords(
+		[][]string{
+			{"date", "value"},
+			{"2021-03-01T00:00:00Z", "1"},
+			{"2020-12-31T10:30:00Z", "2"},
+			{"NaN", "3"},
+			{"2021-01-15T00:00:00Z", "4"},
+		},
+	)
+	if err := a.Error(); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	if expected := []series.Type{series.Time, series.Int}; !reflect.DeepEqual(expected, a.Types()) {
+		t.Errorf("Different types:\nA:%v\nB:%v", expected, a.Types())
+	}
+
+	b := a.Arrange(Sort("date"))
+	expected := [][]string{
+		{"date", "value"},
+		{"2020-12-31T10:30:00Z", "2"},
+		{"2021-01-15T00:00:00Z", "4"},
+		{"2021-03-01T00:00:00Z", "1"},
+		{"NaN", "3"},
+	}
+	if !reflect.DeepEqual(expected, b.Records()) {
+		t.Errorf("Different values:\nA:%v\nB:%v", expected, b.Records())
+	}
+
+	c := a.FilterAggregation(And, Between("date", "2021-01-01T00:00:00Z", "2021-03-01T00:00:00Z")...)
+	expected = [][]string{
+		{"date", "value"},
+		{"2021-03-01T00:00:00Z", "1"},
+		{"2021-01-15T00:00:00Z", "4"},
+	}
+	if !reflect.DeepEqual(expected, c.Records()) {
+		t.Errorf("Different values:\nA:%v\nB:%v", expected, c.Records())
+	}
+}
+
+func TestDataFrame_DescribeTime(t *testing.T) {
+	a := LoadRecords(
+		[][]string{
+			{"date", "value"},
+			{"2021-03-01T00:00:00Z", "1"},
+			{"2020-12-31T10:30:00Z", "2"},
+			{"NaN", "3"},
+		},
+	)
+	b := a.Describe()
+	if err := b.Error(); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	expected := []string{"-", "-", "-", "2020-12-31T10:30:00Z", "-", "-", "-", "2021-03-01T00:00:00Z"}
+	if received := b.Col("date").Records(); !reflect.DeepEqual(expected, received) {
+		t.Errorf("Different values:\nA:%v\nB:%v", expected, received)
+	}
+	expTypes := []series.Type{series.String, series.String, series.Float}
+	if !reflect.DeepEqual(expTypes, b.Types()) {
+		t.Errorf("Different types:\nA:%v\nB:%v", expTypes, b.Types())
+	}
+}
+
+func TestLoadRecords_TimeLayout(t *testing.T) {
+	records := [][]string{
+		{"date", "mixed"},
+		{"01/02/2006", "01/02/2006"},
+		{"31/12/2020", "1"},
+	}
+	a := LoadRecords(records, TimeLayout("02/01/2006"))
+	if err := a.Error(); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	if expected := []series.Type{series.Time, series.String}; !reflect.DeepEqual(expected, a.Types()) {
+		t.Errorf("Different types:\nA:%v\nB:%v", expected, a.Types())
+	}
+	if expected := []string{"2006-02-01T00:00:00Z", "2020-12-31T00:00:00Z"}; !reflect.DeepEqual(expected, a.Col("date").Records()) {
+		t.Errorf("Different values:\nA:%v\nB:%v", expected, a.Col("date").Records())
+	}
+
+	b := LoadRecords(records)
+	if expected := []series.Type{series.String, series.String}; !reflect.DeepEqual(expected, b.Types()) {
+		t.Errorf("Different types:\nA:%v\nB:%v", expected, b.Types())
+	}
+}
+
+func TestLoadRecords_Uint(t *testing.T) {
+	records := [][]string{
+		{"id", "count", "mixed"},
+		{"18446744073709551615", "1", "18446744073709551615"},
+		{"2", "NaN", "-1"},
+	}
+	a := LoadRecords(records)
+	if err := a.Error(); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	expTypes := []series.Type{series.Uint, series.Int, series.Float}
+	if !reflect.DeepEqual(expTypes, a.Types()) {
+		t.Errorf("Different types:\nA:%v\nB:%v", expTypes, a.Types())
+	}
+	if expected := []string{"18446744073709551615", "2"}; !reflect.DeepEqual(expected, a.Col("id").Records()) {
+		t.Errorf("Different values:\nA:%v\nB:%v", expected, a.Col("id").Records())
+	}
+}
+
+func TestDataFrame_DescribeUint(t *testing.T) {
+	a := LoadRecords(
+		[][]string{
+			{"id"},
+			{"18446744073709551615"},
+			{"2"},
+		},
+	)
+	b := a.Describe()
+	if err := b.Error(); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	expTypes := []series.Type{series.String, series.Float}
+	if !reflect.DeepEqual(expTypes, b.Types()) {
+		t.Errorf("Different types:\nA:%v\nB:%v", expTypes, b.Types())
+	}
+	if received := b.Col("id").Records(); received[3] != "2.000000" {
+		t.Errorf("Unexpected min: %v", received)
+	}
+}
+
+func TestDataFrame_RApplyUint(t *testing.T) {
+	a := New(
+		series.New([]string{"18446744073709551615", "2"}, series.Uint, "id"),
+		series.New([]string{"7", "8"}, series.Uint, "count"),
+	)
+	b := a.RApply(func(s series.Series1) series.Series1 { return s })
+	if err := b.Error(); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	if expected := []series.Type{series.Uint, series.Uint}; !reflect.DeepEqual(expected, b.Types()) {
+		t.Errorf("Different types:\nA:%v\nB:%v", expected, b.Types())
+	}
+
+	c := New(
+		series.New([]string{"18446744073709551615", "2"}, series.Uint, "id"),
+		series.New([]int{-1, 3}, series.Int, "delta"),
+	).RApply(func(s series.Series1) series.Series1 { return s })
+	if err := c.Error(); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	if expected := []series.Type{series.Float, series.Float}; !reflect.DeepEqual(expected, c.Types()) {
+		t.Errorf("Different types:\nA:%v\nB:%v", expected, c.Types())
+	}
+}
+
+func TestDataFrame_Astype(t *testing.T) {
+	a := New(
+		series.New([]int{10, 20, 30}, series.Int, "id"),
+		series.New([]string{"1.5", "NaN", "3"}, series.String, "amount"),
+		series.New([]float64{1.0, 2.5, 3.0}, series.Float, "score"),
+		series.New([]float64{1.0, 2.0, 3.0}, series.Float, "count"),
+	)
+	table := []struct {
+		colname string
+		t       series.Type
+		expCol  series.Series1
+	}{
+		{
+			"id",
+			series.String,
+			series.New([]string{"10", "20", "30"}, series.String, "id"),
+		},
+		{
+			"amount",
+			series.Float,
+			series.New([]interface{}{1.5, nil, 3.0}, series.Float, "amount"),
+		},
+		{
+			"count",
+			series.Int,
+			series.New([]int{1, 2, 3}, series.Int, "count"),
+		},
+	}
+	for i, tc := range table {
+		b := a.Astype(tc.colname, tc.t)
+
+		if err := b.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(a.Names(), b.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, a.Names(), b.Names())
+		}
+		col := b.Col(tc.colname)
+		if col.Type() != tc.t {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, tc.t, col.Type())
+		}
+		if !reflect.DeepEqual(tc.expCol.Records(), col.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expCol.Records(), col.Records())
+		}
+	}
+
+	if err := a.Astype("score", series.Int).Error(); err == nil {
+		t.Errorf("Expected error due to non integral values")
+	}
+	if err := a.Astype("amount", series.Int).Error(); err == nil {
+		t.Errorf("Expected error due to non integer strings")
+	}
+	if err := a.Astype("unknown", series.Int).Error(); err == nil {
+		t.Errorf("Expected error due to unknown column name")
+	}
+}
+
+func TestDataFrame_MemoryUsage(t *testing.T) {
+	a := New(
+		series.New([]string{"a fairly long string", "another long string", "and one more"}, series.String, "text"),
+		series.New([]int{1, 2, 3}, series.Int, "ints"),
+		series.New([]bool{true, false, true}, series.Bool, "bools"),
+	)
+	usage := a.MemoryUsage()
+
+	for _, name := range a.Names() {
+		if usage[name] <= 0 {
+			t.Errorf("Expected positive usage for column %s, got %d", name, usage[name])
+		}
+	}
+	if usage["text"] <= usage["ints"] {
+		t.Errorf("Expected text column (%d) to use more memory than ints column (%d)", usage["text"], usage["ints"])
+	}
+	if usage["ints"] <= usage["bools"] {
+		t.Errorf("Expected ints column (%d) to use more memory than bools column (%d)", usage["ints"], usage["bools"])
+	}
+	if total := usage["text"] + usage["ints"] + usage["bools"]; usage[MemoryUsageTotal] != total {
+		t.Errorf("Expected total %d, got %d", total, usage[MemoryUsageTotal])
+	}
+}
+
+func TestDataFrame_InnerJoinHash(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	n := 200
+	aKeys := make([]interface{}, n)
+	aNames := make([]string, n)
+	aValues := make([]float64, n)
+	for i := 0; i < n; i++ {
+		aKeys[i] = r.Intn(50)
+		if i%37 == 0 {
+			aKeys[i] = nil
+		}
+		aNames[i] = strconv.Itoa(r.Intn(3))
+		aValues[i] = r.Float64()
+	}
+	bKeys := make([]interface{}, n)
+	bNames := make([]string, n)
+	bValues := make([]int, n)
+	for i := 0; i < n; i++ {
+		bKeys[i] = r.Intn(50)
+		if i%41 == 0 {
+			bKeys[i] = nil
+		}
+		bNames[i] = strconv.Itoa(r.Intn(3))
+		bValues[i] = r.Int()
+	}
+	a := New(
+		series.New(aKeys, series.Int, "key"),
+		series.New(aNames, series.String, "name"),
+		series.New(aValues, series.Float, "A"),
+	)
+	b := New(
+		series.New(bKeys, series.Int, "key"),
+		series.New(bNames, series.String, "name"),
+		series.New(bValues, series.Int, "B"),
+	)
+
+	for _, keys := range [][]string{{"key"}, {"key", "name"}} {
+		iKeysA, iKeysB, err := joinKeyIndexes(a, b, keys, keys)
+		if err != nil {
+			t.Fatalf("Keys: %v\nError:%v", keys, err)
+		}
+		expected, err := nestedLoopJoinPairs(context.Background(), a.columns, b.columns, iKeysA, iKeysB, a.NRow(), b.NRow())
+		if err != nil {
+			t.Fatalf("Keys: %v\nError:%v", keys, err)
+		}
+		received, err := hashJoinPairs(context.Background(), a.columns, b.columns, iKeysA, iKeysB, a.NRow(), b.NRow())
+		if err != nil {
+			t.Fatalf("Keys: %v\nError:%v", keys, err)
+		}
+		if len(expected) == 0 {
+			t.Fatalf("Keys: %v\nExpected some matching rows", keys)
+		}
+		if !reflect.DeepEqual(expected, received) {
+			t.Errorf("Keys: %v\nDifferent pairs:\nA:%v\nB:%v", keys, expected, received)
+		}
+
+		c := a.InnerJoin(b, keys...)
+		if err := c.Error(); err != nil {
+			t.Fatalf("Keys: %v\nError:%v", keys, err)
+		}
+		if c.NRow() != len(expected) {
+			t.Errorf("Keys: %v\nExpected %d rows, got %d", keys, len(expected), c.NRow())
+		}
+	}
+}
+
+func TestDataFrame_JoinOn(t *testing.T) {
+	a := New(
+		series.New([]int{1, 2, 3, 1}, series.Int, "customer_id"),
+		series.New([]string{"x", "y", "x", "y"}, series.String, "region"),
+		series.New([]float64{10, 20, 30, 40}, series.Float, "amount"),
+	)
+	b := New(
+		series.New([]int{1, 2, 1, 4}, series.Int, "id"),
+		series.New([]string{"x", "y", "y", "z"}, series.String, "zone"),
+		series.New([]string{"ann", "bob", "ann-y", "dan"}, series.String, "name"),
+	)
+	table := []struct {
+		join  func() DataFrame
+		expDf DataFrame
+	}{
+		{
+			func() DataFrame { return a.InnerJoinOn(b, []string{"customer_id"}, []string{"id"}) },
+			New(
+				series.New([]int{1, 1, 2, 1, 1}, series.Int, "customer_id"),
+				series.New([]string{"x", "x", "y", "y", "y"}, series.String, "region"),
+				series.New([]float64{10, 10, 20, 40, 40}, series.Float, "amount"),
+				series.New([]string{"x", "y", "y", "x", "y"}, series.String, "zone"),
+				series.New([]string{"ann", "ann-y", "bob", "ann", "ann-y"}, series.String, "name"),
+			),
+		},
+		{
+			func() DataFrame {
+				return a.InnerJoinOn(b, []string{"customer_id", "region"}, []string{"id", "zone"})
+			},
+			New(
+				series.New([]int{1, 2, 1}, series.Int, "customer_id"),
+				series.New([]string{"x", "y", "y"}, series.String, "region"),
+				series.New([]float64{10, 20, 40}, series.Float, "amount"),
+				series.New([]string{"ann", "bob", "ann-y"}, series.String, "name"),
+			),
+		},
+		{
+			func() DataFrame {
+				return a.LeftJoinOn(b, []string{"customer_id", "region"}, []string{"id", "zone"})
+			},
+			New(
+				series.New([]int{1, 2, 3, 1}, series.Int, "customer_id"),
+				series.New([]string{"x", "y", "x", "y"}, series.String, "region"),
+				series.New([]float64{10, 20, 30, 40}, series.Float, "amount"),
+				series.New([]interface{}{"ann", "bob", nil, "ann-y"}, series.String, "name"),
+			),
+		},
+		{
+			func() DataFrame { return a.InnerJoinOn(b, []string{"customer_id", "region"}, []string{"id"}) },
+			nil,
+		},
+		{
+			func() DataFrame { return a.InnerJoinOn(b, []string{"customer_id"}, []string{"customer_id"}) },
+			nil,
+		},
+	}
+	for i, tc := range table {
+		c := tc.join()
+
+		if tc.expDf == nil {
+			if c.Error() == nil {
+				t.Errorf("Test: %d\nExpected error", i)
+			}
+			continue
+		}
+		if err := c.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+		}
+		if !reflect.DeepEqual(tc.expDf.Types(), c.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, tc.expDf.Types(), c.Types())
+		}
+		if !reflect.DeepEqual(tc.expDf.Names(), c.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expDf.Names(), c.Names())
+		}
+		if !reflect.DeepEqual(tc.expDf.Records(), c.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expDf.Records(), c.Records())
+		}
+	}
+}
+
+func TestDataFrame_JoinSuffixes(t *testing.T) {
+	a := New(
+		series.New([]int{1, 2}, series.Int, "id"),
+		series.New([]string{"a", "b"}, series.String, "name"),
+		series.New([]float64{1.5, 2.5}, series.Float, "A"),
+	)
+	b := New(
+		series.New([]int{2, 1}, series.Int, "id"),
+		series.New([]string{"B", "A"}, series.String, "name"),
+		series.New([]int{20, 10}, series.Int, "B"),
+	)
+	table := []struct {
+		join     func() DataFrame
+		expNames []string
+	}{
+		{
+			func() DataFrame { return a.InnerJoin(b, "id") },
+			[]string{"id", "name_0", "A", "name_1", "B"},
+		},
+		{
+			func() DataFrame { return a.InnerJoinOn(b, []string{"id"}, []string{"id"}, Suffixes("_x", "_y")) },
+			[]string{"id", "name_x", "A", "name_y", "B"},
+		},
+		{
+			func() DataFrame { return a.OuterJoinOn(b, []string{"id"}, []string{"id"}, Suffixes("_left", "_right")) },
+			[]string{"id", "name_left", "A", "name_right", "B"},
+		},
+		{
+			func() DataFrame { return a.LeftJoinOn(b, []string{"id"}, []string{"B"}, Suffixes("_x", "_y")) },
+			[]string{"id", "name_x", "A", "id_y", "name_y"},
+		},
+	}
+	for i, tc := range table {
+		c := tc.join()
+
+		if err := c.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(tc.expNames, c.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expNames, c.Names())
+		}
+	}
+
+	c := a.InnerJoinOn(b, []string{"id"}, []string{"id"}, Suffixes("_x", "_y"))
+	expected := [][]string{
+		{"id", "name_x", "A", "name_y", "B"},
+		{"1", "a", "1.500000", "A", "10"},
+		{"2", "b", "2.500000", "B", "20"},
+	}
+	if !reflect.DeepEqual(expected, c.Records()) {
+		t.Errorf("Different values:\nA:%v\nB:%v", expected, c.Records())
+	}
+}
+
+func TestDataFrame_LeftJoinNaNTypes(t *testing.T) {
+	a := New(
+		series.New([]int{1, 2, 3}, series.Int, "id"),
+	)
+	b := New(
+		series.New([]int{1}, series.Int, "id"),
+		series.New([]int{10}, series.Int, "I"),
+		series.New([]float64{1.5}, series.Float, "F"),
+		series.New([]string{"x"}, series.String, "S"),
+		series.New([]bool{true}, series.Bool, "B"),
+	)
+	c := a.LeftJoin(b, "id")
+	if err := c.Error(); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+
+	expTypes := []series.Type{series.Int, series.Int, series.Float, series.String, series.Bool}
+	if !reflect.DeepEqual(expTypes, c.Types()) {
+		t.Errorf("Different types:\nA:%v\nB:%v", expTypes, c.Types())
+	}
+	for _, colname := range []string{"I", "F", "S", "B"} {
+		expected := []bool{false, true, true}
+		if received := c.Col(colname).IsNaN(); !reflect.DeepEqual(expected, received) {
+			t.Errorf("Column %s: different NaN values:\nA:%v\nB:%v", colname, expected, received)
+		}
+	}
+}
+
+func TestDataFrame_Coalesce(t *testing.T) {
+	a := LoadRecords(
+		[][]string{
+			{"A", "B", "C", "D"},
+			{"1", "NaN", "NaN", "x"},
+			{"NaN", "2.5", "3", "y"},
+			{"NaN", "NaN", "4", "NaN"},
+			{"NaN", "NaN", "NaN", "NaN"},
+			{"5", "6.5", "7", "z"},
+		},
+		WithTypes(map[string]series.Type{
+			"A": series.Int,
+			"B": series.Float,
+			"C": series.Int,
+			"D": series.String,
+		}),
+	)
+	table := []struct {
+		newname  string
+		colnames []string
+		expType  series.Type
+		expRecs  []string
+	}{
+		{
+			"X",
+			[]string{"A", "B", "C"},
+			series.Float,
+			[]string{"1.000000", "2.500000", "4.000000", "NaN", "5.000000"},
+		},
+		{
+			"X",
+			[]string{"C", "A"},
+			series.Int,
+			[]string{"1", "3", "4", "NaN", "7"},
+		},
+		{
+			"X",
+			[]string{"D", "A", "C"},
+			series.String,
+			[]string{"x", "y", "4", "NaN", "z"},
+		},
+		{
+			"A",
+			[]string{"A", "C"},
+			series.Int,
+			[]string{"1", "3", "4", "NaN", "5"},
+		},
+	}
+	for i, tc := range table {
+		b := a.Coalesce(tc.newname, tc.colnames...)
+
+		if err := b.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+			continue
+		}
+		if tc.newname == "X" && b.NCol() != a.NCol()+1 {
+			t.Errorf("Test: %d\nExpected a new column, got %d columns", i, b.NCol())
+		}
+		col := b.Col(tc.newname)
+		if col.Type() != tc.expType {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, tc.expType, col.Type())
+		}
+		if !reflect.DeepEqual(tc.expRecs, col.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expRecs, col.Records())
+		}
+	}
+
+	if b := a.Coalesce("X"); b.Error() == nil {
+		t.Errorf("Expected error for missing column names")
+	}
+	if b := a.Coalesce("X", "A", "unknown"); b.Error() == nil {
+		t.Errorf("Expected error for unknown column name")
+	}
+}
+
+func TestDataFrame_SplitColumn(t *testing.T) {
+	a := New(
+		series.New([]string{"Ada Lovelace", "Alan Mathison Turing", "Grace", "NaN"}, series.String, "name"),
+		series.New([]int{1, 2, 3, 4}, series.Int, "id"),
+	)
+	table := []struct {
+		options []SplitOption
+		expDf   DataFrame
+	}{
+		{
+			nil,
+			LoadRecords(
+				[][]string{
+					{"name", "id", "first", "last"},
+					{"Ada Lovelace", "1", "Ada", "Lovelace"},
+					{"Alan Mathison Turing", "2", "Alan", "Mathison"},
+					{"Grace", "3", "Grace", "NaN"},
+					{"NaN", "4", "NaN", "NaN"},
+				},
+				WithTypes(map[string]series.Type{
+					"name":  series.String,
+					"first": series.String,
+					"last":  series.String,
+				}),
+			),
+		},
+		{
+			[]SplitOption{KeepRemainder(true)},
+			LoadRecords(
+				[][]string{
+					{"name", "id", "first", "last"},
+					{"Ada Lovelace", "1", "Ada", "Lovelace"},
+					{"Alan Mathison Turing", "2", "Alan", "Mathison Turing"},
+					{"Grace", "3", "Grace", "NaN"},
+					{"NaN", "4", "NaN", "NaN"},
+				},
+				WithTypes(map[string]series.Type{
+					"name":  series.String,
+					"first": series.String,
+					"last":  series.String,
+				}),
+			),
+		},
+	}
+	for i, tc := range table {
+		b := a.SplitColumn("name", " ", []string{"first", "last"}, tc.options...)
+
+		if err := b.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(tc.expDf.Types(), b.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, tc.expDf.Types(), b.Types())
+		}
+		if !reflect.DeepEqual(tc.expDf.Names(), b.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expDf.Names(), b.Names())
+		}
+		if !reflect.DeepEqual(tc.expDf.Records(), b.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expDf.Records(), b.Records())
+		}
+	}
+
+	errTests := []DataFrame{
+		a.SplitColumn("unknown", " ", []string{"first"}),
+		a.SplitColumn("id", " ", []string{"first"}),
+		a.SplitColumn("name", " ", nil),
+		a.SplitColumn("name", " ", []string{"first", "id"}),
+	}
+	for i, b := range errTests {
+		if b.Error() == nil {
+			t.Errorf("Test: %d\nExpected error", i)
+		}
+	}
+}
+
+func TestDataFrame_GetDummies(t *testing.T) {
+	a := New(
+		series.New([]int{1, 2, 3, 4, 5}, series.Int, "id"),
+		series.New([]string{"red", "blue", "NaN", "red", "green"}, series.String, "color"),
+		series.New([]float64{1.5, 2.5, 3.5, 4.5, 5.5}, series.Float, "size"),
+	)
+	table := []struct {
+		prefix   string
+		options  []DummiesOption
+		expNames []string
+		expNaN   []int
+	}{
+		{
+			"c",
+			nil,
+			[]string{"id", "c_blue", "c_green", "c_red", "size"},
+			[]int{0, 0, 0},
+		},
+		{
+			"",
+			nil,
+			[]string{"id", "color_blue", "color_green", "color_red", "size"},
+			[]int{0, 0, 0},
+		},
+		{
+			"c",
+			[]DummiesOption{DummyNA(true)},
+			[]string{"id", "c_blue", "c_green", "c_red", "c_NaN", "size"},
+			[]int{0, 0, 0, 1},
+		},
+	}
+	for i, tc := range table {
+		b := a.GetDummies("color", tc.prefix, tc.options...)
+
+		if err := b.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(tc.expNames, b.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expNames, b.Names())
+			continue
+		}
+		indicators := tc.expNames[1 : len(tc.expNames)-1]
+		for _, colname := range indicators {
+			if typ := b.Col(colname).Type(); typ != series.Int {
+				t.Errorf("Test: %d\nColumn %s: expected type Int, got %v", i, colname, typ)
+			}
+		}
+		for row := 0; row < b.NRow(); row++ {
+			var set []int
+			for _, colname := range indicators {
+				set = append(set, b.Col(colname).Elem(row).Val().(int))
+			}
+			if row == 2 {
+				if !reflect.DeepEqual(tc.expNaN, set) {
+					t.Errorf("Test: %d\nNaN row: expected indicators %v, got %v", i, tc.expNaN, set)
+				}
+				continue
+			}
+			sum := 0
+			for j, v := range set {
+				sum += v
+				if v == 1 && !strings.HasSuffix(indicators[j], "_"+a.Col("color").Records()[row]) {
+					t.Errorf("Test: %d\nRow %d: unexpected indicator %s set", i, row, indicators[j])
+				}
+			}
+			if sum != 1 {
+				t.Errorf("Test: %d\nRow %d: expected exactly one indicator set, got %v", i, row, set)
+			}
+		}
+	}
+
+	if b := a.GetDummies("unknown", ""); b.Error() == nil {
+		t.Errorf("Expected error for unknown column name")
+	}
+}
+
+func TestDataFrame_Pipe(t *testing.T) {
+	a := New(
+		series.New([]string{"b", "a", "c", "d"}, series.String, "COL.1"),
+		series.New([]int{1, 2, 3, 4}, series.Int, "COL.2"),
+		series.New([]float64{3.0, 4.0, 5.3, 1.2}, series.Float, "COL.3"),
+	)
+	selectCols := func(df DataFrame) DataFrame {
+		return df.Select([]string{"COL.1", "COL.2"})
+	}
+	filterRows := func(df DataFrame) DataFrame {
+		return df.Filter(F{Colname: "COL.2", Comparator: series.Greater, Comparando: 1})
+	}
+	mutateCol := func(df DataFrame) DataFrame {
+		return df.Mutate(series.New([]string{"x", "y", "z"}, series.String, "COL.1"))
+	}
+
+	b := a.Pipe(selectCols, filterRows, mutateCol)
+	if err := b.Error(); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	expected := [][]string{
+		{"COL.1", "COL.2"},
+		{"x", "2"},
+		{"y", "3"},
+		{"z", "4"},
+	}
+	if !reflect.DeepEqual(expected, b.Records()) {
+		t.Errorf("Different values:\nA:%v\nB:%v", expected, b.Records())
+	}
+
+	if b := a.Pipe(); !reflect.DeepEqual(a.Records(), b.Records()) {
+		t.Errorf("Different values:\nA:%v\nB:%v", a.Records(), b.Records())
+	}
+
+	called := false
+	failing := func(df DataFrame) DataFrame {
+		return df.Select("unknown")
+	}
+	last := func(df DataFrame) DataFrame {
+		called = true
+		return df
+	}
+	b = a.Pipe(selectCols, failing, last)
+	if err := b.Error(); err == nil || !strings.Contains(err.Error(), "stage 1") {
+		t.Errorf("Expected error on stage 1, got %v", err)
+	}
+	if called {
+		t.Errorf("Expected stages after the failing one not to be called")
+	}
+}
+
+func TestDataFrame_InnerJoinCtx(t *testing.T) {
+	a := New(
+		series.New([]int{1, 2, 3}, series.Int, "key"),
+		series.New([]string{"a", "b", "c"}, series.String, "A"),
+	)
+	b := New(
+		series.New([]int{3, 1}, series.Int, "key"),
+		series.New([]float64{3.5, 1.5}, series.Float, "B"),
+	)
+
+	c, err := a.InnerJoinCtx(context.Background(), b, "key")
+	if err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	if expected := a.InnerJoin(b, "key").Records(); !reflect.DeepEqual(expected, c.Records()) {
+		t.Errorf("Different values:\nA:%v\nB:%v", expected, c.Records())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := a.InnerJoinCtx(ctx, b, "key"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled error, got %v", err)
+	}
+
+	// Keys of different types are matched with a nested loop, which checks the
+	// context once per row, so it is canceled midway.
+	n := 100
+	aKeys := make([]int, n)
+	bKeys := make([]float64, n)
+	for i := 0; i < n; i++ {
+		aKeys[i] = i
+		bKeys[i] = float64(n - i)
+	}
+	big := New(series.New(aKeys, series.Int, "key"))
+	bigB := New(series.New(bKeys, series.Float, "key"))
+	countdown := &countdownContext{Context: context.Background(), remaining: 10}
+	_, err = big.InnerJoinCtx(countdown, bigB, "key")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled error, got %v", err)
+	}
+	if countdown.calls != countdown.remaining+1 {
+		t.Errorf("Expected join to stop at the first canceled check, checked %d times", countdown.calls)
+	}
+}
+
+// countdownContext is a context whose Err starts returning context.Canceled
+// once it has been called more than remaining times, which cancels operations
+// at a deterministic point.
+type countdownContext struct {
+	context.Context
+	remaining int
+	calls     int
+}
+
+func (c *countdownContext) Err() error {
+	c.calls++
+	if c.calls > c.remaining {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestGroups_AggregationCtx(t *testing.T) {
+	a := New(
+		series.New([]string{"b", "a", "b", "a", "b"}, series.String, "key"),
+		series.New([]float64{3.0, 4.0, 5.3, 3.2, 1.2}, series.Float, "values"),
+	)
+	groups := a.GroupBy("key")
+	typs := []AggregationType{Aggregation_MAX}
+	colnames := []string{"values"}
+
+	df, err := groups.AggregationCtx(context.Background(), typs, colnames)
+	if err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	if expected := groups.Aggregation(typs, colnames).Records(); !reflect.DeepEqual(expected, df.Records()) {
+		t.Errorf("Different values:\nA:%v\nB:%v", expected, df.Records())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := groups.AggregationCtx(ctx, typs, colnames); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled error, got %v", err)
+	}
+}
+
+func TestDataFrame_Equal(t *testing.T) {
+	records := [][]string{
+		{"A", "B", "C"},
+		{"a", "1", "1.5"},
+		{"b", "NaN", "2.5"},
+		{"NaN", "3", "NaN"},
+	}
+	a := LoadRecords(records)
+	table := []struct {
+		b        DataFrame
+		tol      float64
+		expEqual bool
+		expApprx bool
+	}{
+		{LoadRecords(records), 0.1, true, true},
+		{a.Copy(), 0, true, true},
+		{a.SetAt(0, "A", "z"), 0.1, false, false},
+		{a.SetAt(2, "B", 3), 0.1, false, false},
+		{a.SetAt(0, "C", 1.55), 0.1, false, true},
+		{a.SetAt(0, "C", 1.7), 0.1, false, false},
+		{a.SetAt(2, "C", 0.0), 0.1, false, false},
+		{a.Rename("D", "C"), 0.1, false, false},
+		{a.Astype("B", series.Float), 0.1, false, false},
+		{a.Select([]string{"A", "B"}), 0.1, false, false},
+		{a.Head(2), 0.1, false, false},
+		{a.Select([]string{"B", "A", "C"}), 0.1, false, false},
+		{GotaDataFrame{Err: fmt.Errorf("error")}, 0.1, false, false},
+	}
+	for i, tc := range table {
+		if err := tc.b.Error(); err != nil && i < len(table)-1 {
+			t.Fatalf("Test: %d\nError:%v", i, err)
+		}
+		if received := a.Equal(tc.b); received != tc.expEqual {
+			t.Errorf("Test: %d\nEqual: expected %v, got %v", i, tc.expEqual, received)
+		}
+		if received := a.EqualApprox(tc.b, tc.tol); received != tc.expApprx {
+			t.Errorf("Test: %d\nEqualApprox: expected %v, got %v", i, tc.expApprx, received)
+		}
+	}
+}
+
+func TestDataFrame_Hash(t *testing.T) {
+	records := [][]string{
+		{"A", "B", "C"},
+		{"a", "1", "1.5"},
+		{"b", "NaN", "2.5"},
+		{"NaN", "3", "NaN"},
+	}
+	a := LoadRecords(records)
+	if h := LoadRecords(records).Hash(); h != a.Hash() {
+		t.Errorf("Expected identical DataFrames to have the same hash, got %d and %d", a.Hash(), h)
+	}
+	if h := a.Copy().Hash(); h != a.Hash() {
+		t.Errorf("Expected copies to have the same hash, got %d and %d", a.Hash(), h)
+	}
+
+	table := []DataFrame{
+		a.SetAt(0, "A", "z"),
+		a.SetAt(1, "B", 2),
+		a.SetAt(0, "C", 1.5000001),
+		a.SetAt(2, "A", "NaN "),
+		a.Rename("D", "C"),
+		a.Astype("B", series.Float),
+		a.Reverse(),
+		a.Select([]string{"B", "A", "C"}),
+		a.Head(2),
+	}
+	for i, b := range table {
+		if err := b.Error(); err != nil {
+			t.Fatalf("Test: %d\nError:%v", i, err)
+		}
+		if b.Hash() == a.Hash() {
+			t.Errorf("Test: %d\nExpected different hash, got %d for both", i, a.Hash())
+		}
+	}
+}
+
+func TestDataFrame_Shuffle(t *testing.T) {
+	n := 20
+	ids := make([]int, n)
+	names := make([]string, n)
+	for i := range ids {
+		ids[i] = i
+		names[i] = "row" + strconv.Itoa(i)
+	}
+	a := New(
+		series.New(ids, series.Int, "id"),
+		series.New(names, series.String, "name"),
+	)
+
+	b := a.Shuffle(7)
+	if err := b.Error(); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	if c := a.Shuffle(7); !reflect.DeepEqual(b.Records(), c.Records()) {
+		t.Errorf("Expected the same permutation for the same seed:\n%v\n%v", b.Records(), c.Records())
+	}
+	if reflect.DeepEqual(a.Records(), b.Records()) {
+		t.Errorf("Expected the rows to be permuted")
+	}
+	if !reflect.DeepEqual(a.Arrange(Sort("id")).Records(), b.Arrange(Sort("id")).Records()) {
+		t.Errorf("Expected the same rows after shuffling")
+	}
+	for i, row := range b.Records()[1:] {
+		if row[1] != "row"+row[0] {
+			t.Errorf("Row %d: columns are not aligned: %v", i, row)
+		}
+	}
+}
+
+func TestDataFrame_TrainTestSplit(t *testing.T) {
+	n := 50
+	ids := make([]int, n)
+	for i := range ids {
+		ids[i] = i
+	}
+	a := New(
+		series.New(ids, series.Int, "id"),
+		series.New(ids, series.Float, "value"),
+	)
+
+	for _, frac := range []float64{0.2, 0.5, 0.75} {
+		train, test := a.TrainTestSplit(frac, 3)
+		if err := train.Error(); err != nil {
+			t.Fatalf("Frac: %v\nError:%v", frac, err)
+		}
+		if err := test.Error(); err != nil {
+			t.Fatalf("Frac: %v\nError:%v", frac, err)
+		}
+		if expected := int(frac * float64(n)); train.NRow() != expected {
+			t.Errorf("Frac: %v\nExpected %d train rows, got %d", frac, expected, train.NRow())
+		}
+		if train.NRow()+test.NRow() != n {
+			t.Errorf("Frac: %v\nExpected %d rows in total, got %d", frac, n, train.NRow()+test.NRow())
+		}
+
+		seen := make(map[string]bool)
+		for _, df := range []DataFrame{train, test} {
+			for _, id := range df.Col("id").Records() {
+				if seen[id] {
+					t.Errorf("Frac: %v\nRow %s is in both DataFrames", frac, id)
+				}
+				seen[id] = true
+			}
+		}
+
+		train2, test2 := a.TrainTestSplit(frac, 3)
+		if !reflect.DeepEqual(train.Records(), train2.Records()) || !reflect.DeepEqual(test.Records(), test2.Records()) {
+			t.Errorf("Frac: %v\nExpected the same partition for the same seed", frac)
+		}
+	}
+
+	for _, frac := range []float64{-0.5, 0, 1, 1.5} {
+		train, test := a.TrainTestSplit(frac, 3)
+		if train.Error() == nil || test.Error() == nil {
+			t.Errorf("Frac: %v\nExpected error", frac)
+		}
+	}
+}
+
+func TestDataFrame_Explode(t *testing.T) {
+	a := New(
+		series.New([]int{1, 2, 3, 4}, series.Int, "id"),
+		series.New([]string{"a;b;c", "d", "", "NaN"}, series.String, "tags"),
+		series.New([]float64{1.5, 2.5, 3.5, 4.5}, series.Float, "value"),
+	)
+	table := []struct {
+		options []ExplodeOption
+		expDf   DataFrame
+	}{
+		{
+			nil,
+			New(
+				series.New([]int{1, 1, 1, 2, 3, 4}, series.Int, "id"),
+				series.New([]string{"a", "b", "c", "d", "NaN", "NaN"}, series.String, "tags"),
+				series.New([]float64{1.5, 1.5, 1.5, 2.5, 3.5, 4.5}, series.Float, "value"),
+			),
+		},
+		{
+			[]ExplodeOption{DropEmpty(true)},
+			New(
+				series.New([]int{1, 1, 1, 2}, series.Int, "id"),
+				series.New([]string{"a", "b", "c", "d"}, series.String, "tags"),
+				series.New([]float64{1.5, 1.5, 1.5, 2.5}, series.Float, "value"),
+			),
+		},
+	}
+	for i, tc := range table {
+		b := a.Explode("tags", ";", tc.options...)
+
+		if err := b.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(tc.expDf.Types(), b.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, tc.expDf.Types(), b.Types())
+		}
+		if !reflect.DeepEqual(tc.expDf.Names(), b.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expDf.Names(), b.Names())
+		}
+		if !reflect.DeepEqual(tc.expDf.Records(), b.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expDf.Records(), b.Records())
+		}
+	}
+
+	if b := a.Explode("unknown", ";"); b.Error() == nil {
+		t.Errorf("Expected error for unknown column name")
+	}
+	if b := a.Explode("id", ";"); b.Error() == nil {
+		t.Errorf("Expected error for non string column")
+	}
+}
+
+func TestDataFrame_PivotTable(t *testing.T) {
+	a := New(
+		series.New([]string{"x", "x", "x", "y", "y", "z"}, series.String, "id"),
+		series.New([]string{"a", "a", "b", "a", "b", "a"}, series.String, "key"),
+		series.New([]int{1, 2, 3, 4, 5, 6}, series.Int, "value"),
+	)
+	table := []struct {
+		agg   AggregationType
+		expDf DataFrame
+	}{
+		{
+			Aggregation_SUM,
+			New(
+				series.New([]string{"x", "y", "z"}, series.String, "id"),
+				series.New([]float64{3.0, 4.0, 6.0}, series.Float, "a"),
+				series.New([]string{"3.0", "5.0", "NaN"}, series.Float, "b"),
+			),
+		},
+		{
+			Aggregation_MEAN,
+			New(
+				series.New([]string{"x", "y", "z"}, series.String, "id"),
+				series.New([]float64{1.5, 4.0, 6.0}, series.Float, "a"),
+				series.New([]string{"3.0", "5.0", "NaN"}, series.Float, "b"),
+			),
+		},
+		{
+			Aggregation_COUNT,
+			New(
+				series.New([]string{"x", "y", "z"}, series.String, "id"),
+				series.New([]float64{2.0, 1.0, 1.0}, series.Float, "a"),
+				series.New([]string{"1.0", "1.0", "NaN"}, series.Float, "b"),
+			),
+		},
+	}
+	for i, tc := range table {
+		b := a.PivotTable("id", "key", "value", tc.agg)
+
+		if err := b.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(tc.expDf.Types(), b.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, tc.expDf.Types(), b.Types())
+		}
+		if !reflect.DeepEqual(tc.expDf.Names(), b.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expDf.Names(), b.Names())
+		}
+		if !reflect.DeepEqual(tc.expDf.Records(), b.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expDf.Records(), b.Records())
+		}
+	}
+
+	if b := a.PivotTable("id", "key", "unknown", Aggregation_SUM); b.Error() == nil {
+		t.Errorf("Expected error for unknown column name")
+	}
+	if b := a.PivotTable("id", "key", "value", AggregationType(100)); b.Error() == nil {
+		t.Errorf("Expected error for unknown aggregation")
+	}
+}
+
+func TestReadJSONLines(t *testing.T) {
+	jsonStr := `{"COL.1":null,"COL.2":1,"COL.3":"a"}
+{"COL.1":5,"COL.2":2,"COL.3":"b"}
+
+{"COL.2":3,"COL.3":"c"}
+`
+	expDf := LoadRecords(
+		[][]string{
+			{"COL.1", "COL.2", "COL.3"},
+			{"NaN", "1", "a"},
+			{"5", "2", "b"},
+			{"NaN", "3", "c"},
+		},
+	)
+	c := ReadJSONLines(strings.NewReader(jsonStr))
+	if err := c.Error(); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	if !reflect.DeepEqual(expDf.Types(), c.Types()) {
+		t.Errorf("Different types:\nA:%v\nB:%v", expDf.Types(), c.Types())
+	}
+	if !reflect.DeepEqual(expDf.Names(), c.Names()) {
+		t.Errorf("Different colnames:\nA:%v\nB:%v", expDf.Names(), c.Names())
+	}
+	if !reflect.DeepEqual(expDf.Records(), c.Records()) {
+		t.Errorf("Different values:\nA:%v\nB:%v", expDf.Records(), c.Records())
+	}
+
+	// The last line doesn't need a trailing newline
+	c = ReadJSONLines(strings.NewReader("{\"A\":1}\r\n{\"A\":2}"))
+	if expected := [][]string{{"A"}, {"1"}, {"2"}}; !reflect.DeepEqual(expected, c.Records()) {
+		t.Errorf("Different values:\nA:%v\nB:%v", expected, c.Records())
+	}
+
+	errTable := []struct {
+		jsonStr string
+		line    string
+	}{
+		{"{\"A\":1}\n{\"A\":\n{\"A\":3}\n", "line 2"},
+		{"{\"A\":1}\n\n{\"A\":2} {\"A\":3}\n", "line 3"},
+		{"[{\"A\":1}]\n", "line 1"},
+	}
+	for i, tc := range errTable {
+		c := ReadJSONLines(strings.NewReader(tc.jsonStr))
+		if err := c.Error(); err == nil || !strings.Contains(err.Error(), tc.line) {
+			t.Errorf("Test: %d\nExpected error on %s, got %v", i, tc.line, err)
+		}
+	}
+}
+
+func TestReadJSON_FlattenJSON(t *testing.T) {
+	jsonStr := `[
+		{"name":"ada","address":{"city":"London","geo":{"lat":51.5}},"tags":["x","y"]},
+		{"name":"alan","address":{"city":"Wilmslow"},"tags":["z"]}
+	]`
+	table := []struct {
+		sep   string
+		expDf DataFrame
+	}{
+		{
+			".",
+			LoadRecords(
+				[][]string{
+					{"address.city", "address.geo.lat", "name", "tags.0", "tags.1"},
+					{"London", "51.5", "ada", "x", "y"},
+					{"Wilmslow", "NaN", "alan", "z", "NaN"},
+				},
+			),
+		},
+		{
+			"_",
+			LoadRecords(
+				[][]string{
+					{"address_city", "address_geo_lat", "name", "tags_0", "tags_1"},
+					{"London", "51.5", "ada", "x", "y"},
+					{"Wilmslow", "NaN", "alan", "z", "NaN"},
+				},
+			),
+		},
+	}
+	for i, tc := range table {
+		c := ReadJSON(strings.NewReader(jsonStr), FlattenJSON(tc.sep))
+
+		if err := c.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(tc.expDf.Types(), c.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, tc.expDf.Types(), c.Types())
+		}
+		if !reflect.DeepEqual(tc.expDf.Names(), c.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expDf.Names(), c.Names())
+		}
+		if !reflect.DeepEqual(tc.expDf.Records(), c.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expDf.Records(), c.Records())
+		}
+	}
+
+	// Without the option nested objects are kept on a single column
+	c := ReadJSON(strings.NewReader(jsonStr))
+	if expected := []string{"address", "name", "tags"}; !reflect.DeepEqual(expected, c.Names()) {
+		t.Errorf("Different colnames:\nA:%v\nB:%v", expected, c.Names())
+	}
+}
+
+func TestReadJSON_PreserveOrder(t *testing.T) {
+	jsonStr := `[{"z":1,"b":"x","m":{"y":1.5,"a":2}},{"z":2,"k":true,"b":"y"}]`
+	table := []struct {
+		options  []LoadOption
+		expNames []string
+	}{
+		{
+			nil,
+			[]string{"b", "k", "m", "z"},
+		},
+		{
+			[]LoadOption{PreserveOrder(false)},
+			[]string{"b", "k", "m", "z"},
+		},
+		{
+			[]LoadOption{PreserveOrder(true)},
+			[]string{"z", "b", "m", "k"},
+		},
+		{
+			[]LoadOption{PreserveOrder(true), FlattenJSON(".")},
+			[]string{"z", "b", "m.y", "m.a", "k"},
+		},
+	}
+	for i, tc := range table {
+		c := ReadJSON(strings.NewReader(jsonStr), tc.options...)
+		if err := c.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(tc.expNames, c.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expNames, c.Names())
+		}
+		if expected := []string{"1", "2"}; !reflect.DeepEqual(expected, c.Col("z").Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, expected, c.Col("z").Records())
+		}
+
+		lines := ReadJSONLines(strings.NewReader("{\"z\":1,\"b\":\"x\",\"m\":{\"y\":1.5,\"a\":2}}\n{\"z\":2,\"k\":true,\"b\":\"y\"}\n"), tc.options...)
+		if err := lines.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(tc.expNames, lines.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expNames, lines.Names())
+		}
+	}
+
+	if c := ReadJSON(strings.NewReader(`[{"a":1},`), PreserveOrder(true)); c.Error() == nil {
+		t.Errorf("Expected error for malformed JSON")
+	}
+}
+
+func TestReadFWF(t *testing.T) {
+	fwfStr := `Name      Age Amount
+Alice      30  112.5
+Bob       7    18.25
+# comment line
+
+Carol      41
+`
+	widths := []int{10, 3, 7}
+	expDf := LoadRecords(
+		[][]string{
+			{"Name", "Age", "Amount"},
+			{"Alice", "30", "112.5"},
+			{"Bob", "7", "18.25"},
+			{"Carol", "41", "NaN"},
+		},
+	)
+	a := ReadFWF(strings.NewReader(fwfStr), widths, WithComments('#'))
+	if err := a.Error(); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	if expected := []series.Type{series.String, series.Int, series.Float}; !reflect.DeepEqual(expected, a.Types()) {
+		t.Errorf("Different types:\nA:%v\nB:%v", expected, a.Types())
+	}
+	if !reflect.DeepEqual(expDf.Names(), a.Names()) {
+		t.Errorf("Different colnames:\nA:%v\nB:%v", expDf.Names(), a.Names())
+	}
+	if !reflect.DeepEqual(expDf.Records(), a.Records()) {
+		t.Errorf("Different values:\nA:%v\nB:%v", expDf.Records(), a.Records())
+	}
+
+	b := ReadFWF(strings.NewReader(fwfStr), widths, WithComments('#'), StrictWidths(true))
+	if err := b.Error(); err == nil || !strings.Contains(err.Error(), "line 6") {
+		t.Errorf("Expected error on line 6, got %v", err)
+	}
+
+	for _, widths := range [][]int{nil, {10, 0, 7}} {
+		if c := ReadFWF(strings.NewReader(fwfStr), widths); c.Error() == nil {
+			t.Errorf("Widths: %v\nExpected error", widths)
+		}
+	}
+}
+
+func TestReadTSV(t *testing.T) {
+	tsvStr := "Country\tAge\tAmount\n" +
+		"United States\t50\t112.1\n" +
+		"\"United Kingdom\"\tNA\t18.2\n" +
+		"Spain, Madrid\t66\t555.42\n"
+	table := []struct {
+		options []LoadOption
+	}{
+		{nil},
+		{[]LoadOption{DetectTypes(false), DefaultType(series.String)}},
+		{[]LoadOption{HasHeader(false)}},
+		{[]LoadOption{WithDelimiter(';')}},
+	}
+	for i, tc := range table {
+		a := ReadTSV(strings.NewReader(tsvStr), tc.options...)
+		b := ReadCSV(strings.NewReader(tsvStr), append(tc.options, WithDelimiter('\t'))...)
+
+		if err := a.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+			continue
+		}
+		if a.NCol() != 3 {
+			t.Errorf("Test: %d\nExpected 3 columns, got %d", i, a.NCol())
+		}
+		if !reflect.DeepEqual(b.Types(), a.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, b.Types(), a.Types())
+		}
+		if !reflect.DeepEqual(b.Names(), a.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, b.Names(), a.Names())
+		}
+		if !reflect.DeepEqual(b.Records(), a.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, b.Records(), a.Records())
+		}
+	}
+}
+
+func TestReadCSVGzip(t *testing.T) {
+	csvStr := `Country,Age,Amount
+United States,50,112.1
+United Kingdom,NA,18.2
+Spain,66,555.42
+`
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write([]byte(csvStr)); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	compressed := buf.Bytes()
+
+	a := ReadCSVGzip(bytes.NewReader(compressed))
+	if err := a.Error(); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	b := ReadCSV(strings.NewReader(csvStr))
+	if !reflect.DeepEqual(b.Types(), a.Types()) {
+		t.Errorf("Different types:\nA:%v\nB:%v", b.Types(), a.Types())
+	}
+	if !reflect.DeepEqual(b.Records(), a.Records()) {
+		t.Errorf("Different values:\nA:%v\nB:%v", b.Records(), a.Records())
+	}
+
+	errTable := []io.Reader{
+		strings.NewReader(csvStr),
+		bytes.NewReader(compressed[:len(compressed)-10]),
+		bytes.NewReader(nil),
+	}
+	for i, r := range errTable {
+		if c := ReadCSVGzip(r); c.Error() == nil {
+			t.Errorf("Test: %d\nExpected error", i)
+		}
+	}
+}
+
+func TestReadCSV_MaxRows(t *testing.T) {
+	csvStr := `A,B
+1,x
+2,y
+3.5,z
+4,w
+`
+	table := []struct {
+		options []LoadOption
+		expDf   DataFrame
+	}{
+		{
+			[]LoadOption{MaxRows(2)},
+			LoadRecords([][]string{{"A", "B"}, {"1", "x"}, {"2", "y"}}),
+		},
+		{
+			[]LoadOption{MaxRows(3)},
+			LoadRecords([][]string{{"A", "B"}, {"1", "x"}, {"2", "y"}, {"3.5", "z"}}),
+		},
+		{
+			[]LoadOption{MaxRows(10)},
+			ReadCSV(strings.NewReader(csvStr)),
+		},
+		{
+			[]LoadOption{MaxRows(0)},
+			ReadCSV(strings.NewReader(csvStr)),
+		},
+		{
+			[]LoadOption{MaxRows(2), HasHeader(false)},
+			LoadRecords([][]string{{"A", "B"}, {"1", "x"}}, HasHeader(false)),
+		},
+	}
+	for i, tc := range table {
+		b := ReadCSV(strings.NewReader(csvStr), tc.options...)
+
+		if err := b.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(tc.expDf.Types(), b.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, tc.expDf.Types(), b.Types())
+		}
+		if !reflect.DeepEqual(tc.expDf.Names(), b.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expDf.Names(), b.Names())
+		}
+		if !reflect.DeepEqual(tc.expDf.Records(), b.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expDf.Records(), b.Records())
+		}
+	}
+
+	// Rows after the limit are not read, so they can't cause errors
+	if b := ReadCSV(strings.NewReader("A,B\n1,2\n3,4,5\n"), MaxRows(1)); b.Error() != nil {
+		t.Errorf("Unexpected error: %v", b.Error())
+	}
+}
+
+func TestReadCSV_SkipRows(t *testing.T) {
+	csvStr := `Sales report
+Generated on 2024-01-01
+A,B,C
+1,x,true
+2,y,false
+3.5,z,true
+Total,6.5
+`
+	expDf := LoadRecords([][]string{
+		{"A", "B", "C"},
+		{"1", "x", "true"},
+		{"2", "y", "false"},
+		{"3.5", "z", "true"},
+	})
+	table := []struct {
+		df    DataFrame
+		expDf DataFrame
+	}{
+		{
+			ReadCSV(strings.NewReader(csvStr), SkipRows(2), SkipFooter(1)),
+			expDf,
+		},
+		{
+			LoadRecords([][]string{
+				{"Sales report"},
+				{"Generated on 2024-01-01"},
+				{"A", "B", "C"},
+				{"1", "x", "true"},
+				{"2", "y", "false"},
+				{"3.5", "z", "true"},
+				{"Total", "6.5"},
+			}, SkipRows(2), SkipFooter(1)),
+			expDf,
+		},
+		{
+			ReadCSV(strings.NewReader(csvStr), SkipRows(3), SkipFooter(1), HasHeader(false)),
+			LoadRecords([][]string{
+				{"1", "x", "true"},
+				{"2", "y", "false"},
+				{"3.5", "z", "true"},
+			}, HasHeader(false)),
+		},
+		{
+			ReadCSV(strings.NewReader(csvStr), SkipRows(2), SkipFooter(1), MaxRows(2)),
+			LoadRecords([][]string{
+				{"A", "B", "C"},
+				{"1", "x", "true"},
+				{"2", "y", "false"},
+			}),
+		},
+	}
+	for i, tc := range table {
+		b := tc.df
+
+		if err := b.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(tc.expDf.Types(), b.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, tc.expDf.Types(), b.Types())
+		}
+		if !reflect.DeepEqual(tc.expDf.Names(), b.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expDf.Names(), b.Names())
+		}
+		if !reflect.DeepEqual(tc.expDf.Records(), b.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expDf.Records(), b.Records())
+		}
+	}
+
+	// Skipping every row leaves nothing to load
+	if b := ReadCSV(strings.NewReader(csvStr), SkipRows(5), SkipFooter(2)); b.Error() == nil {
+		t.Errorf("Expected error skipping every row")
+	}
+}
+
+func TestReadCSVStream_SkipRows(t *testing.T) {
+	csvStr := `Sales report
+Generated on 2024-01-01
+id,name
+1,a
+2,b
+3,c
+4,d
+5,e
+Total,15,units
+`
+	table := []struct {
+		batch    int
+		expected [][]string
+	}{
+		{2, [][]string{{"1", "2"}, {"3", "4"}, {"5"}}},
+		{5, [][]string{{"1", "2", "3", "4", "5"}}},
+		{10, [][]string{{"1", "2", "3", "4", "5"}}},
+	}
+	for i, tc := range table {
+		var received [][]string
+		err := ReadCSVStream(strings.NewReader(csvStr), tc.batch, func(df GotaDataFrame) error {
+			if err := df.Error(); err != nil {
+				return err
+			}
+			if expNames := []string{"id", "name"}; !reflect.DeepEqual(expNames, df.Names()) {
+				t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, expNames, df.Names())
+			}
+			if expTypes := []series.Type{series.Int, series.String}; !reflect.DeepEqual(expTypes, df.Types()) {
+				t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, expTypes, df.Types())
+			}
+			received = append(received, df.Col("id").Records())
+			return nil
+		}, SkipRows(2), SkipFooter(1))
+		if err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+		}
+		if !reflect.DeepEqual(tc.expected, received) {
+			t.Errorf("Test: %d\nDifferent batches:\nA:%v\nB:%v", i, tc.expected, received)
+		}
+	}
+
+	// Without SkipFooter the footer is a row with the wrong number of fields
+	err := ReadCSVStream(strings.NewReader(csvStr), 2, func(df GotaDataFrame) error { return nil }, SkipRows(2))
+	if err == nil {
+		t.Errorf("Expected error reading the footer")
+	}
+}
+
+func TestLoadRecords_NumberSeparators(t *testing.T) {
+	table := []struct {
+		records [][]string
+		options []LoadOption
+		expDf   DataFrame
+	}{
+		{
+			[][]string{
+				{"A", "B", "C"},
+				{"1,234.56", "1,000", "a,b"},
+				{"-7,654,321.5", "12", "c.d"},
+				{"0.25", "NA", "e"},
+			},
+			[]LoadOption{ThousandsSep(',')},
+			New(
+				series.New([]float64{1234.56, -7654321.5, 0.25}, series.Float, "A"),
+				series.New([]interface{}{1000, 12, nil}, series.Int, "B"),
+				series.New([]string{"a,b", "c.d", "e"}, series.String, "C"),
+			),
+		},
+		{
+			[][]string{
+				{"A", "B", "C"},
+				{"1.234,56", "1.000", "a,b"},
+				{"-7.654.321,5", "12", "c.d"},
+				{"0,25", "NA", "e"},
+			},
+			[]LoadOption{ThousandsSep('.'), DecimalSep(',')},
+			New(
+				series.New([]float64{1234.56, -7654321.5, 0.25}, series.Float, "A"),
+				series.New([]interface{}{1000, 12, nil}, series.Int, "B"),
+				series.New([]string{"a,b", "c.d", "e"}, series.String, "C"),
+			),
+		},
+		{
+			[][]string{
+				{"A"},
+				{"1,5"},
+				{"2"},
+			},
+			[]LoadOption{DecimalSep(',')},
+			New(
+				series.New([]float64{1.5, 2}, series.Float, "A"),
+			),
+		},
+	}
+	for i, tc := range table {
+		b := LoadRecords(tc.records, tc.options...)
+
+		if err := b.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(tc.expDf.Types(), b.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, tc.expDf.Types(), b.Types())
+		}
+		if !reflect.DeepEqual(tc.expDf.Names(), b.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expDf.Names(), b.Names())
+		}
+		if !reflect.DeepEqual(tc.expDf.Records(), b.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expDf.Records(), b.Records())
+		}
+	}
+
+	// Columns typed as String keep their values, while numeric ones are
+	// normalized
+	b := LoadRecords(
+		[][]string{
+			{"id", "A", "B"},
+			{"1.234", "1.234", "1.234"},
+			{"5", "5", "5"},
+		},
+		ThousandsSep('.'),
+		WithTypes(map[string]series.Type{"id": series.String, "B": series.Float}),
+	)
+	if err := b.Error(); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	expDf := New(
+		series.New([]string{"1.234", "5"}, series.String, "id"),
+		series.New([]int{1234, 5}, series.Int, "A"),
+		series.New([]float64{1234, 5}, series.Float, "B"),
+	)
+	if !reflect.DeepEqual(expDf.Types(), b.Types()) {
+		t.Errorf("Different types:\nA:%v\nB:%v", expDf.Types(), b.Types())
+	}
+	if !reflect.DeepEqual(expDf.Records(), b.Records()) {
+		t.Errorf("Different values:\nA:%v\nB:%v", expDf.Records(), b.Records())
+	}
+
+	// European formatted CSV files use a different delimiter
+	csvStr := "A;B\n1.234,56;x\n2,5;y\n"
+	b = ReadCSV(strings.NewReader(csvStr), WithDelimiter(';'), ThousandsSep('.'), DecimalSep(','))
+	if err := b.Error(); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	if got := b.Col("A").Float(); !reflect.DeepEqual(got, []float64{1234.56, 2.5}) {
+		t.Errorf("Different values:\nA:%v\nB:%v", []float64{1234.56, 2.5}, got)
+	}
+}
+
+func TestLoadStructs_Nested(t *testing.T) {
+	type Base struct {
+		ID   int
+		Kind string `dataframe:"kind"`
+	}
+	type Address struct {
+		City string
+		Zip  int `dataframe:"zip"`
+		note string
+	}
+	type Person struct {
+		Base
+		Name    string
+		Home    Address `dataframe:"home"`
+		Work    Address
+		Skipped Address `dataframe:"-"`
+		Tagged  Base    `dataframe:"tagged,string"`
+	}
+	type hidden struct {
+		Level int
+	}
+	type Nested struct {
+		hidden
+		Named Base `dataframe:"named"`
+	}
+
+	table := []struct {
+		b     GotaDataFrame
+		expDf GotaDataFrame
+	}{
+		{
+			LoadStructs([]Person{
+				{Base{1, "a"}, "Alice", Address{"Berlin", 10115, ""}, Address{"Paris", 75001, ""}, Address{}, Base{}},
+				{Base{2, "b"}, "Bob", Address{"Rome", 118, ""}, Address{"NA", 0, ""}, Address{}, Base{}},
+			}),
+			New(
+				series.New([]int{1, 2}, series.Int, "ID"),
+				series.New([]string{"a", "b"}, series.String, "kind"),
+				series.New([]string{"Alice", "Bob"}, series.String, "Name"),
+				series.New([]string{"Berlin", "Rome"}, series.String, "home.City"),
+				series.New([]int{10115, 118}, series.Int, "home.zip"),
+				series.New([]interface{}{"Paris", nil}, series.String, "Work.City"),
+				series.New([]int{75001, 0}, series.Int, "Work.zip"),
+				series.New([]interface{}{nil, nil}, series.String, "tagged"),
+			),
+		},
+		{
+			LoadStructs([]Nested{
+				{hidden{3}, Base{4, "c"}},
+			}),
+			New(
+				series.New([]int{3}, series.Int, "Level"),
+				series.New([]int{4}, series.Int, "named.ID"),
+				series.New([]string{"c"}, series.String, "named.kind"),
+			),
+		},
+	}
+	for i, tc := range table {
+		if tc.b.Err != nil {
+			t.Errorf("Test: %d\nError:%v", i, tc.b.Err)
+			continue
+		}
+		if !reflect.DeepEqual(tc.expDf.Types(), tc.b.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, tc.expDf.Types(), tc.b.Types())
+		}
+		if !reflect.DeepEqual(tc.expDf.Names(), tc.b.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expDf.Names(), tc.b.Names())
+		}
+		if !reflect.DeepEqual(tc.expDf.Records(), tc.b.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expDf.Records(), tc.b.Records())
+		}
+	}
+}
+
+func TestLoadStructs_PointersAndTimes(t *testing.T) {
+	type testStruct struct {
+		A *int
+		B *string
+		C time.Time
+		D time.Time `dataframe:",string"`
+		E *time.Time
+	}
+	one, x := 1, "x"
+	t1 := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	t2 := time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC)
+	data := []testStruct{
+		{&one, nil, t1, t1, &t2},
+		{nil, &x, t2, t2, nil},
+	}
+
+	table := []struct {
+		b     GotaDataFrame
+		expDf GotaDataFrame
+	}{
+		{
+			LoadStructs(data),
+			New(
+				series.New([]interface{}{1, nil}, series.Int, "A"),
+				series.New([]interface{}{nil, "x"}, series.String, "B"),
+				series.New([]interface{}{t1, t2}, series.Time, "C"),
+				series.New([]string{"2024-01-02T03:04:05Z", "2024-02-03T00:00:00Z"}, series.String, "D"),
+				series.New([]interface{}{t2, nil}, series.Time, "E"),
+			),
+		},
+		{
+			LoadStructs(data, TimeLayout("2006-01-02")),
+			New(
+				series.New([]interface{}{1, nil}, series.Int, "A"),
+				series.New([]interface{}{nil, "x"}, series.String, "B"),
+				series.New([]interface{}{t1, t2}, series.Time, "C"),
+				series.New([]string{"2024-01-02", "2024-02-03"}, series.String, "D"),
+				series.New([]interface{}{t2, nil}, series.Time, "E"),
+			),
+		},
+	}
+	for i, tc := range table {
+		if tc.b.Err != nil {
+			t.Errorf("Test: %d\nError:%v", i, tc.b.Err)
+			continue
+		}
+		if !reflect.DeepEqual(tc.expDf.Types(), tc.b.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, tc.expDf.Types(), tc.b.Types())
+		}
+		if !reflect.DeepEqual(tc.expDf.Names(), tc.b.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expDf.Names(), tc.b.Names())
+		}
+		if !reflect.DeepEqual(tc.expDf.Records(), tc.b.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expDf.Records(), tc.b.Records())
+		}
+	}
+}
+
+func TestDataFrame_FillStructs(t *testing.T) {
+	type Base struct {
+		ID int
+	}
+	type Address struct {
+		City string
+	}
+	type testStruct struct {
+		Base
+		Name    string `dataframe:"name"`
+		Score   float64
+		Active  bool
+		Count   *int
+		When    time.Time
+		Home    Address `dataframe:"home"`
+		Ignored string  `dataframe:"-"`
+	}
+	two := 2
+	when := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	data := []testStruct{
+		{Base{1}, "a", 0.5, true, &two, when, Address{"Berlin"}, ""},
+		{Base{2}, "b", 1.5, false, nil, time.Time{}, Address{"Rome"}, ""},
+	}
+
+	df := LoadStructs(data)
+	if err := df.Error(); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	var got []testStruct
+	if err := df.FillStructs(&got); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	if !reflect.DeepEqual(data, got) {
+		t.Errorf("Different values:\nA:%v\nB:%v", data, got)
+	}
+
+	// Columns are converted to the types of the fields, and NaN elements set
+	// the zero value
+	type converted struct {
+		A int8
+		B string
+		C float32
+	}
+	df = New(
+		series.New([]interface{}{"1", nil}, series.String, "A"),
+		series.New([]interface{}{1, 2}, series.Int, "B"),
+		series.New([]interface{}{nil, 2}, series.Int, "C"),
+	)
+	var conv []converted
+	if err := df.FillStructs(&conv); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	if expected := []converted{{1, "1", 0}, {0, "2", 2}}; !reflect.DeepEqual(expected, conv) {
+		t.Errorf("Different values:\nA:%v\nB:%v", expected, conv)
+	}
+
+	// Errors
+	type missing struct {
+		A int
+		D string
+	}
+	var miss []missing
+	if err := df.FillStructs(&miss); err == nil {
+		t.Errorf("Expected error for a field without column")
+	}
+	if err := df.FillStructs(conv); err == nil {
+		t.Errorf("Expected error for a non pointer argument")
+	}
+	var ints []int
+	if err := df.FillStructs(&ints); err == nil {
+		t.Errorf("Expected error for a slice of non structs")
+	}
+	type overflow struct {
+		B uint8
+	}
+	var over []overflow
+	if err := New(series.New([]int{300}, series.Int, "B")).FillStructs(&over); err == nil {
+		t.Errorf("Expected error for an overflowing value")
+	}
+	if err := New(series.New([]int{-1}, series.Int, "B")).FillStructs(&over); err == nil {
+		t.Errorf("Expected error for a negative value")
+	}
+
+	// Unsigned values beyond the range of int round-trip through Uint columns
+	type unsigned struct {
+		U uint64
+		V uint
+	}
+	df = LoadRecords([][]string{
+		{"U", "V"},
+		{"18446744073709551615", "7"},
+		{"9223372036854775808", "NA"},
+	})
+	if expected := []series.Type{series.Uint, series.Int}; !reflect.DeepEqual(expected, df.Types()) {
+		t.Errorf("Different types:\nA:%v\nB:%v", expected, df.Types())
+	}
+	var uns []unsigned
+	if err := df.FillStructs(&uns); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	if expected := []unsigned{{math.MaxUint64, 7}, {1 << 63, 0}}; !reflect.DeepEqual(expected, uns) {
+		t.Errorf("Different values:\nA:%v\nB:%v", expected, uns)
+	}
+}
+
+func TestDataFrame_ToMatrix(t *testing.T) {
+	a := New(
+		series.New([]int{1, 2, 3}, series.Int, "A"),
+		series.New([]interface{}{0.5, nil, 2.5}, series.Float, "B"),
+	)
+	m, err := a.ToMatrix()
+	if err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	nrows, ncols := a.Dims()
+	if r, c := m.Dims(); r != nrows || c != ncols {
+		t.Errorf("Different dimensions:\nA:%v, %v\nB:%v, %v", nrows, ncols, r, c)
+	}
+	for i := 0; i < nrows; i++ {
+		for j := 0; j < ncols; j++ {
+			if expected, got := a.Elem(i, j).Float(), m.At(i, j); !compareFloats(expected, got, 6) {
+				t.Errorf("Different values at (%d, %d):\nA:%v\nB:%v", i, j, expected, got)
+			}
+		}
+	}
+
+	// The Matrix can be used with gonum
+	gm, ok := m.(mat.Matrix)
+	if !ok {
+		t.Fatalf("Matrix doesn't implement mat.Matrix")
+	}
+	if r, c := gm.T().Dims(); r != ncols || c != nrows {
+		t.Errorf("Different transposed dimensions:\nA:%v, %v\nB:%v, %v", ncols, nrows, r, c)
+	}
+	if got := gm.T().At(1, 2); got != 2.5 {
+		t.Errorf("Different transposed value:\nA:%v\nB:%v", 2.5, got)
+	}
+
+	// Uint columns are numeric too
+	u := LoadRecords([][]string{{"A", "B"}, {"18446744073709551615", "1"}, {"2", "2"}})
+	m, err = u.ToMatrix()
+	if err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	if got := m.At(0, 0); got != math.MaxUint64 {
+		t.Errorf("Different value:\nA:%v\nB:%v", float64(math.MaxUint64), got)
+	}
+	if got := m.At(1, 1); got != 2 {
+		t.Errorf("Different value:\nA:%v\nB:%v", 2.0, got)
+	}
+
+	// Non numeric columns can't be converted
+	b := New(
+		series.New([]int{1, 2, 3}, series.Int, "A"),
+		series.New([]string{"a", "b", "c"}, series.String, "B"),
+	)
+	if _, err := b.ToMatrix(); err == nil {
+		t.Errorf("Expected error for a non numeric column")
+	}
+}
+
+func TestDataFrame_SelectNumeric(t *testing.T) {
+	a := New(
+		series.New([]string{"a", "b"}, series.String, "A"),
+		series.New([]int{1, 2}, series.Int, "B"),
+		series.New([]bool{true, false}, series.Bool, "C"),
+		series.New([]float64{0.5, 1.5}, series.Float, "D"),
+		series.New([]string{"c", "d"}, series.String, "E"),
+	)
+	table := []struct {
+		df    DataFrame
+		expDf DataFrame
+	}{
+		{
+			a.SelectNumeric(),
+			New(
+				series.New([]int{1, 2}, series.Int, "B"),
+				series.New([]float64{0.5, 1.5}, series.Float, "D"),
+			),
+		},
+		{
+			a.SelectString(),
+			New(
+				series.New([]string{"a", "b"}, series.String, "A"),
+				series.New([]string{"c", "d"}, series.String, "E"),
+			),
+		},
+	}
+	for i, tc := range table {
+		b := tc.df
+
+		if err := b.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(tc.expDf.Types(), b.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, tc.expDf.Types(), b.Types())
+		}
+		if !reflect.DeepEqual(tc.expDf.Names(), b.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expDf.Names(), b.Names())
+		}
+		if !reflect.DeepEqual(tc.expDf.Records(), b.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expDf.Records(), b.Records())
+		}
+	}
+
+	// Uint columns are numeric too
+	u := LoadRecords([][]string{{"A", "B"}, {"18446744073709551615", "x"}})
+	if names := u.SelectNumeric().Names(); !reflect.DeepEqual([]string{"A"}, names) {
+		t.Errorf("Different colnames:\nA:%v\nB:%v", []string{"A"}, names)
+	}
+
+	// Without columns of the given kind there is nothing to select
+	b := New(series.New([]bool{true}, series.Bool, "A"))
+	if err := b.SelectNumeric().Error(); err == nil {
+		t.Errorf("Expected error selecting no columns")
+	}
+}
+
+func TestDataFrame_NullCount(t *testing.T) {
+	a := New(
+		series.New([]interface{}{"a", nil, "c", nil}, series.String, "A"),
+		series.New([]interface{}{1, 2, 3, 4}, series.Int, "B"),
+		series.New([]interface{}{nil, 1.5, nil, nil}, series.Float, "C"),
+		series.New([]interface{}{true, nil, false, true}, series.Bool, "D"),
+	)
+
+	expected := map[string]int{"A": 2, "B": 0, "C": 3, "D": 1}
+	if got := a.NullCount(); !reflect.DeepEqual(expected, got) {
+		t.Errorf("Different null counts:\nA:%v\nB:%v", expected, got)
+	}
+
+	expDf := New(
+		series.New([]string{"A", "B", "C", "D"}, series.String, "column"),
+		series.New([]string{string(series.String), string(series.Int), string(series.Float), string(series.Bool)}, series.String, "type"),
+		series.New([]int{2, 4, 1, 3}, series.Int, "non_null"),
+		series.New([]int{2, 0, 3, 1}, series.Int, "null"),
+	)
+	b := a.Info()
+	if err := b.Error(); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	if !reflect.DeepEqual(expDf.Types(), b.Types()) {
+		t.Errorf("Different types:\nA:%v\nB:%v", expDf.Types(), b.Types())
+	}
+	if !reflect.DeepEqual(expDf.Names(), b.Names()) {
+		t.Errorf("Different colnames:\nA:%v\nB:%v", expDf.Names(), b.Names())
+	}
+	if !reflect.DeepEqual(expDf.Records(), b.Records()) {
+		t.Errorf("Different values:\nA:%v\nB:%v", expDf.Records(), b.Records())
+	}
+}
+
+func TestValueCounts(t *testing.T) {
+	table := []struct {
+		df    DataFrame
+		expDf DataFrame
+	}{
+		{
+			ValueCounts(series.Strings("b", "a", "c", "a", "b", "a")),
+			New(
+				series.New([]string{"a", "b", "c"}, series.String, "values"),
+				series.New([]int{3, 2, 1}, series.Int, "counts"),
+			),
+		},
+		{
+			ValueCounts(series.Floats(1.0, math.NaN(), 2.0, math.NaN(), 1.0, math.NaN())),
+			New(
+				series.New([]interface{}{nil, 1.0, 2.0}, series.Float, "values"),
+				series.New([]int{3, 2, 1}, series.Int, "counts"),
+			),
+		},
+		{
+			ValueCounts(series.Floats(1.0, math.NaN(), 2.0, math.NaN(), 1.0, math.NaN()), true),
+			New(
+				series.New([]float64{1.0, 2.0}, series.Float, "values"),
+				series.New([]int{2, 1}, series.Int, "counts"),
+			),
+		},
+		{
+			ValueCounts(series.Ints(7, 7, 8)),
+			New(
+				series.New([]int{7, 8}, series.Int, "values"),
+				series.New([]int{2, 1}, series.Int, "counts"),
+			),
+		},
+	}
+	for i, tc := range table {
+		b := tc.df
+
+		if err := b.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(tc.expDf.Types(), b.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, tc.expDf.Types(), b.Types())
+		}
+		if !reflect.DeepEqual(tc.expDf.Names(), b.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expDf.Names(), b.Names())
+		}
+		if !reflect.DeepEqual(tc.expDf.Records(), b.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expDf.Records(), b.Records())
+		}
+	}
+}
+
+func TestDescribeSeries(t *testing.T) {
+	table := []struct {
+		df    DataFrame
+		expDf DataFrame
+	}{
+		{
+			DescribeSeries(series.Floats(4.0, 1.0, math.NaN(), 3.0, 2.0)),
+			New(
+				series.New([]string{"count", "mean", "std", "min", "25%", "50%", "75%", "max"}, series.String, "statistic"),
+				series.New([]string{"4", "2.5", strconv.FormatFloat(math.Sqrt(5.0/3.0), 'f', -1, 64), "1", "1", "2", "3", "4"}, series.String, "value"),
+			),
+		},
+		{
+			DescribeSeries(series.Strings("b", "a", "b", "c", "b")),
+			New(
+				series.New([]string{"count", "unique", "top", "freq"}, series.String, "statistic"),
+				series.New([]string{"5", "3", "b", "3"}, series.String, "value"),
+			),
+		},
+	}
+	for i, tc := range table {
+		b := tc.df
+
+		if err := b.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(tc.expDf.Types(), b.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, tc.expDf.Types(), b.Types())
+		}
+		if !reflect.DeepEqual(tc.expDf.Names(), b.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, tc.expDf.Names(), b.Names())
+		}
+		if !reflect.DeepEqual(tc.expDf.Records(), b.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, tc.expDf.Records(), b.Records())
+		}
+	}
+}
+
+func TestDataFrame_RBindCopiesElements(t *testing.T) {
+	a := New(
+		series.New([]string{"a", "b"}, series.String, "A"),
+		series.New([]int{1, 2}, series.Int, "B"),
+	)
+	b := New(
+		series.New([]string{"c"}, series.String, "A"),
+		series.New([]int{3}, series.Int, "B"),
+	)
+	expected := [][]string{{"A", "B"}, {"a", "1"}, {"b", "2"}, {"c", "3"}}
+	table := []DataFrame{
+		a.RBind(b),
+		a.Concat(b),
+	}
+
+	// Mutating the sources doesn't change the concatenated DataFrames
+	a.Columns()[0].Elem(0).Set("x")
+	b.Columns()[1].Elem(0).Set(30)
+	for i, c := range table {
+		if err := c.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(expected, c.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, expected, c.Records())
+		}
+	}
+}
+
+func TestReadJSON_PreserveOrderOptions(t *testing.T) {
+	// The caller's options are not modified, even when they have spare
+	// capacity
+	options := make([]LoadOption, 1, 2)
+	options[0] = PreserveOrder(true)
+	readers := []func() DataFrame{
+		func() DataFrame { return ReadJSON(strings.NewReader(`[{"b": 1, "a": 2}]`), options...) },
+		func() DataFrame { return ReadJSONLines(strings.NewReader(`{"b": 1, "a": 2}`), options...) },
+	}
+	for i, read := range readers {
+		df := read()
+		if err := df.Error(); err != nil {
+			t.Errorf("Test: %d\nError:%v", i, err)
+			continue
+		}
+		if expected := []string{"b", "a"}; !reflect.DeepEqual(expected, df.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, expected, df.Names())
+		}
+		if options[:2][1] != nil {
+			t.Errorf("Test: %d\nExpected the options not to be modified", i)
+		}
+	}
+}
@@ -443,17 +443,19 @@ func ExampleDataFrame_Describe() {
 	fmt.Println(df.Describe())
 
 	// Output:
-	// [8x5] DataFrame
+	// [10x5] DataFrame
 	//
 	//     column   A        B        C        D
 	//  0: mean     -        3.250000 6.050000 0.500000
-	//  1: median   -        3.500000 6.000000 NaN
-	//  2: stddev   -        0.957427 0.818535 0.577350
-	//  3: min      a        2.000000 5.100000 0.000000
-	//  4: 25%      -        2.000000 5.100000 0.000000
-	//  5: 50%      -        3.000000 6.000000 0.000000
-	//  6: 75%      -        4.000000 6.000000 1.000000
-	//  7: max      c        4.000000 7.100000 1.000000
-	//     <string> <string> <float>  <float>  <float>
+	//  1: median   -        3.500000 6.000000 -
+	//  2: stddev   -        0.957427 0.818535 -
+	//  3: min      a        2.000000 5.100000 -
+	//  4: 25%      -        2.000000 5.100000 -
+	//  5: 50%      -        3.000000 6.000000 -
+	//  6: 75%      -        4.000000 6.000000 -
+	//  7: max      c        4.000000 7.100000 -
+	//  8: true     -        NaN      NaN      2
+	//  9: false    -        NaN      NaN      2
+	//     <string> <string> <float>  <float>  <string>
 
 }
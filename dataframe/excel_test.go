@@ -0,0 +1,59 @@
+package dataframe
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/go-gota/gota/series"
+)
+
+func TestDataFrame_WriteReadExcel(t *testing.T) {
+	a := New(
+		series.New([]interface{}{"b", nil, "a"}, series.String, "name"),
+		series.New([]interface{}{1, 2, nil}, series.Int, "age"),
+		series.New([]interface{}{3.5, nil, 4.25}, series.Float, "score"),
+		series.New([]interface{}{true, false, nil}, series.Bool, "active"),
+	)
+	table := []struct {
+		sheet string
+		read  string
+	}{
+		{"", ""},
+		{"people", "people"},
+		{"people", ""},
+	}
+	for i, tc := range table {
+		var buf bytes.Buffer
+		if err := a.WriteExcel(&buf, tc.sheet); err != nil {
+			t.Fatalf("Test: %d\nError:%v", i, err)
+		}
+		b := ReadExcel(&buf, tc.read)
+
+		if err := b.Error(); err != nil {
+			t.Fatalf("Test: %d\nError:%v", i, err)
+		}
+		if !reflect.DeepEqual(a.Types(), b.Types()) {
+			t.Errorf("Test: %d\nDifferent types:\nA:%v\nB:%v", i, a.Types(), b.Types())
+		}
+		if !reflect.DeepEqual(a.Names(), b.Names()) {
+			t.Errorf("Test: %d\nDifferent colnames:\nA:%v\nB:%v", i, a.Names(), b.Names())
+		}
+		if !reflect.DeepEqual(a.Records(), b.Records()) {
+			t.Errorf("Test: %d\nDifferent values:\nA:%v\nB:%v", i, a.Records(), b.Records())
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := a.WriteExcel(&buf, "people", WriteHeader(false)); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	b := ReadExcel(bytes.NewReader(buf.Bytes()), "people", HasHeader(false))
+	if nrows, ncols := b.Dims(); nrows != 3 || ncols != 4 {
+		t.Errorf("Expected dimensions 3x4, got %dx%d", nrows, ncols)
+	}
+
+	if err := ReadExcel(bytes.NewReader(buf.Bytes()), "missing").Error(); err == nil {
+		t.Errorf("Expected error due to missing sheet")
+	}
+}
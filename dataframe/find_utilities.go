@@ -3,6 +3,7 @@ package dataframe
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/go-gota/gota/series"
 )
@@ -25,14 +26,19 @@ func inIntSlice(i int, is []int) bool {
 	return false
 }
 
-func findType(arr []string) (series.Type, error) {
-	var hasFloats, hasInts, hasBools, hasStrings bool
+func findType(arr []string, timeLayout string) (series.Type, error) {
+	var hasFloats, hasInts, hasNegativeInts, hasUints, hasBools, hasTimes, hasStrings bool
 	for _, str := range arr {
 		if str == "" || str == "NaN" {
 			continue
 		}
-		if _, err := strconv.Atoi(str); err == nil {
+		if i, err := strconv.Atoi(str); err == nil {
 			hasInts = true
+			hasNegativeInts = hasNegativeInts || i < 0
+			continue
+		}
+		if _, err := strconv.ParseUint(str, 10, 0); err == nil {
+			hasUints = true
 			continue
 		}
 		if _, err := strconv.ParseFloat(str, 64); err == nil {
@@ -43,19 +49,52 @@ func findType(arr []string) (series.Type, error) {
 			hasBools = true
 			continue
 		}
+		if _, err := time.Parse(timeLayout, str); err == nil {
+			hasTimes = true
+			continue
+		}
 		hasStrings = true
 	}
 
 	switch {
 	case hasStrings:
 		return series.String, nil
+	case hasTimes && (hasBools || hasFloats || hasInts || hasUints):
+		return series.String, nil
+	case hasTimes:
+		return series.Time, nil
 	case hasBools:
 		return series.Bool, nil
 	case hasFloats:
 		return series.Float, nil
+	case hasUints && hasNegativeInts:
+		return series.Float, nil
+	case hasUints:
+		return series.Uint, nil
 	case hasInts:
 		return series.Int, nil
 	default:
 		return series.String, fmt.Errorf("couldn't detect type")
 	}
 }
+
+// commonType returns the type that can hold the values of all the given types:
+// the type itself if all of them are equal, Float for a mix of numeric types
+// and String otherwise.
+func commonType(types ...series.Type) series.Type {
+	if len(types) == 0 {
+		return series.String
+	}
+	t := types[0]
+	numeric := true
+	for _, u := range types {
+		if u != t {
+			t = series.Float
+		}
+		numeric = numeric && (u == series.Int || u == series.Uint || u == series.Float)
+	}
+	if t == series.Float && !numeric {
+		return series.String
+	}
+	return t
+}
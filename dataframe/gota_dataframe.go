@@ -1,10 +1,18 @@
 package dataframe
 
 import (
+	"context"
 	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"runtime"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 	"unicode/utf8"
+	"unsafe"
 
 	"github.com/go-gota/gota/series"
 )
@@ -283,6 +291,115 @@ func (df GotaDataFrame) Subset(indexes series.Indexes) DataFrame {
 	}
 }
 
+// Head returns the first n rows of the DataFrame. If n is greater than the
+// number of rows the whole DataFrame is returned, and if it is lower or equal
+// than zero an empty DataFrame with the same columns is returned.
+func (df GotaDataFrame) Head(n int) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	if n > df.nrows {
+		n = df.nrows
+	}
+	if n < 0 {
+		n = 0
+	}
+	idx := make([]int, n)
+	for i := 0; i < n; i++ {
+		idx[i] = i
+	}
+	return df.Subset(idx)
+}
+
+// Slice returns the rows of the DataFrame in the range [start, end). An error is
+// returned if the range is out of bounds or if start is greater than end.
+func (df GotaDataFrame) Slice(start, end int) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	if start > end || start < 0 || end > df.nrows {
+		return GotaDataFrame{Err: fmt.Errorf("slice: index out of bounds")}
+	}
+	idx := make([]int, end-start)
+	for i := range idx {
+		idx[i] = start + i
+	}
+	return df.Subset(idx)
+}
+
+// Tail returns the last n rows of the DataFrame. If n is greater than the
+// number of rows the whole DataFrame is returned, and if it is lower or equal
+// than zero an empty DataFrame with the same columns is returned.
+func (df GotaDataFrame) Tail(n int) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	if n > df.nrows {
+		n = df.nrows
+	}
+	if n < 0 {
+		n = 0
+	}
+	idx := make([]int, n)
+	for i := 0; i < n; i++ {
+		idx[i] = df.nrows - n + i
+	}
+	return df.Subset(idx)
+}
+
+// Sample returns n randomly chosen rows of the DataFrame, without replacement.
+// The given seed is used to initialize the random source, so that the same seed
+// always yields the same rows in the same order.
+func (df GotaDataFrame) Sample(n int, seed int64) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	if n < 0 || n > df.nrows {
+		return GotaDataFrame{Err: fmt.Errorf("sample: can't take %d samples from %d rows", n, df.nrows)}
+	}
+	idx := rand.New(rand.NewSource(seed)).Perm(df.nrows)[:n]
+	return df.Subset(idx)
+}
+
+// SampleFrac returns a random fraction of the rows of the DataFrame, without
+// replacement. See Sample for details.
+func (df GotaDataFrame) SampleFrac(frac float64, seed int64) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	if frac < 0 || frac > 1 {
+		return GotaDataFrame{Err: fmt.Errorf("sample: fraction %v out of range [0, 1]", frac)}
+	}
+	return df.Sample(int(frac*float64(df.nrows)), seed)
+}
+
+// Shuffle returns a copy of the DataFrame with its rows randomly permuted,
+// keeping the elements of every row together. The given seed is used to
+// initialize the random source, so that the same seed always yields the same
+// permutation.
+func (df GotaDataFrame) Shuffle(seed int64) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	return df.Subset(rand.New(rand.NewSource(seed)).Perm(df.nrows))
+}
+
+// TrainTestSplit randomly partitions the rows of the DataFrame in two disjoint
+// DataFrames, where train holds the given fraction of the rows and test the
+// rest. As in Sample, the same seed always yields the same partition.
+func (df GotaDataFrame) TrainTestSplit(frac float64, seed int64) (train, test DataFrame) {
+	if df.Err != nil {
+		return df, df
+	}
+	if frac <= 0 || frac >= 1 {
+		err := GotaDataFrame{Err: fmt.Errorf("train test split: fraction %v out of range (0, 1)", frac)}
+		return err, err
+	}
+	perm := rand.New(rand.NewSource(seed)).Perm(df.nrows)
+	n := int(frac * float64(df.nrows))
+	return df.Subset(perm[:n]), df.Subset(perm[n:])
+}
+
 // Select the given DataFrame columns
 func (df GotaDataFrame) Select(indexes SelectIndexes) DataFrame {
 	if df.Err != nil {
@@ -348,6 +465,187 @@ func (df GotaDataFrame) Drop(indexes SelectIndexes) DataFrame {
 	return df
 }
 
+// SelectNumeric returns a DataFrame with only the Int, Uint and Float columns.
+func (df GotaDataFrame) SelectNumeric() DataFrame {
+	return df.selectTypes(series.Int, series.Uint, series.Float)
+}
+
+// SelectString returns a DataFrame with only the String columns.
+func (df GotaDataFrame) SelectString() DataFrame {
+	return df.selectTypes(series.String)
+}
+
+// selectTypes selects the columns whose type is any of the given types.
+func (df GotaDataFrame) selectTypes(types ...series.Type) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	mask := make([]bool, df.ncols)
+	for i, t := range df.Types() {
+		for _, typ := range types {
+			mask[i] = mask[i] || t == typ
+		}
+	}
+	return df.Select(mask)
+}
+
+// DropNA removes the rows of a DataFrame containing NaN elements on any of the
+// given columns. If no column names are given, all columns are checked.
+func (df GotaDataFrame) DropNA(subset ...string) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	idx, err := parseSelectIndexes(df.ncols, subset, df.Names())
+	if err != nil {
+		return GotaDataFrame{Err: fmt.Errorf("dropna: %v", err)}
+	}
+	if len(subset) == 0 {
+		idx = make([]int, df.ncols)
+		for i := 0; i < df.ncols; i++ {
+			idx[i] = i
+		}
+	}
+
+	keep := make([]bool, df.nrows)
+	for i := range keep {
+		keep[i] = true
+	}
+	for _, i := range idx {
+		for j, isNaN := range df.columns[i].IsNaN() {
+			if isNaN {
+				keep[j] = false
+			}
+		}
+	}
+	return df.Subset(keep)
+}
+
+// Unique returns the DataFrame without duplicated rows, keeping the first
+// occurrence of each of them. If column names are given only those columns are
+// considered when comparing rows. The original row order is preserved.
+func (df GotaDataFrame) Unique(subset ...string) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	dups, err := df.duplicated(subset)
+	if err != nil {
+		return GotaDataFrame{Err: fmt.Errorf("unique: %v", err)}
+	}
+	for i := range dups {
+		dups[i] = !dups[i]
+	}
+	return df.Subset(dups)
+}
+
+// Duplicated returns a BoolSeries marking as true the rows that are repeats of
+// a previous row. If column names are given only those columns are considered
+// when comparing rows.
+func (df GotaDataFrame) Duplicated(subset ...string) series.BoolSeries {
+	dups, err := df.duplicated(subset)
+	if err != nil {
+		return &series.GotaBoolSeries{Err: fmt.Errorf("duplicated: %v", err)}
+	}
+	return series.Bools(dups...)
+}
+
+func (df GotaDataFrame) duplicated(subset []string) ([]bool, error) {
+	if df.Err != nil {
+		return nil, df.Err
+	}
+	idx, err := parseSelectIndexes(df.ncols, subset, df.Names())
+	if err != nil {
+		return nil, err
+	}
+	if len(subset) == 0 {
+		idx = make([]int, df.ncols)
+		for i := 0; i < df.ncols; i++ {
+			idx[i] = i
+		}
+	}
+
+	records := make([][]string, len(idx))
+	for k, i := range idx {
+		records[k] = df.columns[i].Records()
+	}
+	seen := make(map[string]struct{})
+	dups := make([]bool, df.nrows)
+	for j := 0; j < df.nrows; j++ {
+		row := make([]string, len(idx))
+		for k := range idx {
+			row[k] = strconv.Quote(records[k][j])
+		}
+		key := strings.Join(row, ",")
+		if _, ok := seen[key]; ok {
+			dups[j] = true
+			continue
+		}
+		seen[key] = struct{}{}
+	}
+	return dups, nil
+}
+
+// FillNA returns a copy of the DataFrame where the NaN elements of every column
+// are replaced by the given value, converted to the type of each column.
+// Columns without NaN elements are left untouched.
+func (df GotaDataFrame) FillNA(value interface{}) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	values := make(map[string]interface{}, df.ncols)
+	for _, colname := range df.Names() {
+		values[colname] = value
+	}
+	return df.FillNAByColumn(values)
+}
+
+// FillNAByColumn returns a copy of the DataFrame where the NaN elements of the
+// columns given as keys of the map are replaced by the corresponding value,
+// converted to the type of the column.
+func (df GotaDataFrame) FillNAByColumn(values map[string]interface{}) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	for colname := range values {
+		if df.ColIndex(colname) < 0 {
+			return GotaDataFrame{Err: fmt.Errorf("fillna: can't find column name %q", colname)}
+		}
+	}
+
+	columns := make([]series.Series1, df.ncols)
+	for i, col := range df.columns {
+		value, ok := values[col.Name]
+		if !ok || !col.HasNaN() {
+			columns[i] = col.Copy()
+			continue
+		}
+		filled, err := fillNAColumn(col, value)
+		if err != nil {
+			return GotaDataFrame{Err: fmt.Errorf("fillna: %v", err)}
+		}
+		columns[i] = filled
+	}
+	return New(columns...)
+}
+
+// fillNAColumn returns a copy of the Series with its NaN elements replaced by
+// the given value.
+func fillNAColumn(s series.Series1, value interface{}) (series.Series1, error) {
+	fill := series.New(value, s.Type(), s.Name)
+	if fill.Err != nil {
+		return s, fill.Err
+	}
+	if fill.Len() != 1 || fill.HasNaN() {
+		return s, fmt.Errorf("can't convert %v to type %v on column %q", value, s.Type(), s.Name)
+	}
+	ret := s.Copy()
+	for i, isNaN := range s.IsNaN() {
+		if isNaN {
+			ret.Elem(i).Set(fill.Elem(0))
+		}
+	}
+	return ret, nil
+}
+
 // GroupBy Group dataframe by columns
 func (df GotaDataFrame) GroupBy(colnames ...string) *Groups {
 	if len(colnames) <= 0 {
@@ -417,6 +715,41 @@ func (df GotaDataFrame) Rename(newname, oldname string) DataFrame {
 	return copy
 }
 
+// RenameAll changes the names of several columns of a DataFrame at once. The
+// mapping goes from the old column names to the new ones. Every old name must
+// exist and the resulting column names must be unique.
+func (df GotaDataFrame) RenameAll(mapping map[string]string) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	colnames := df.Names()
+	for oldname := range mapping {
+		if findInStringSlice(oldname, colnames) == -1 {
+			return GotaDataFrame{Err: fmt.Errorf("rename all: can't find column name: %s", oldname)}
+		}
+	}
+
+	newnames := make([]string, len(colnames))
+	seen := make(map[string]bool, len(colnames))
+	for i, colname := range colnames {
+		newname, ok := mapping[colname]
+		if !ok {
+			newname = colname
+		}
+		if seen[newname] {
+			return GotaDataFrame{Err: fmt.Errorf("rename all: duplicated column name: %s", newname)}
+		}
+		seen[newname] = true
+		newnames[i] = newname
+	}
+
+	copy := df.Copy()
+	for i, newname := range newnames {
+		copy.Columns()[i].Name = newname
+	}
+	return copy
+}
+
 // CBind combines the columns of this DataFrame and dfb DataFrame.
 func (df GotaDataFrame) CBind(dfb DataFrame) DataFrame {
 	if df.Err != nil {
@@ -538,6 +871,372 @@ func (df GotaDataFrame) Mutate(s series.Series1) DataFrame {
 	return df
 }
 
+// InsertColumn inserts a new column at the given position of a DataFrame,
+// shifting the columns after it to the right. Unlike Mutate, the name of the
+// new column can't already exist on the DataFrame.
+func (df GotaDataFrame) InsertColumn(pos int, s series.Series1) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	if s.Err != nil {
+		return GotaDataFrame{Err: fmt.Errorf("insert column: argument has errors: %v", s.Err)}
+	}
+	if pos < 0 || pos > df.ncols {
+		return GotaDataFrame{Err: fmt.Errorf("insert column: position %d out of range", pos)}
+	}
+	if df.ncols > 0 && s.Len() != df.nrows {
+		return GotaDataFrame{Err: fmt.Errorf("insert column: wrong dimensions")}
+	}
+	if findInStringSlice(s.Name, df.Names()) != -1 {
+		return GotaDataFrame{Err: fmt.Errorf("insert column: column name %s already exists", s.Name)}
+	}
+
+	columns := make([]series.Series1, 0, df.ncols+1)
+	columns = append(columns, df.columns[:pos]...)
+	columns = append(columns, s)
+	columns = append(columns, df.columns[pos:]...)
+	return New(columns...)
+}
+
+// Coalesce adds a column with the given name holding, for every row, the first
+// non NaN value among the given columns, in order. The new column has the
+// common type of those columns, and replaces any existing column with the same
+// name as in Mutate.
+func (df GotaDataFrame) Coalesce(newname string, colnames ...string) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	if len(colnames) == 0 {
+		return GotaDataFrame{Err: fmt.Errorf("coalesce: no column names given")}
+	}
+	cols := make([]series.Series1, len(colnames))
+	types := make([]series.Type, len(colnames))
+	for i, colname := range colnames {
+		idx := df.ColIndex(colname)
+		if idx < 0 {
+			return GotaDataFrame{Err: fmt.Errorf("coalesce: can't find column name %q", colname)}
+		}
+		cols[i] = df.columns[idx]
+		types[i] = cols[i].Type()
+	}
+	t := commonType(types...)
+
+	values := make([]interface{}, df.nrows)
+	for i := range values {
+		for _, col := range cols {
+			e := col.Elem(i)
+			if e.IsNA() {
+				continue
+			}
+			if t == series.String {
+				values[i] = e.String()
+			} else {
+				values[i] = e.Val()
+			}
+			break
+		}
+	}
+	s := series.New(values, t, newname)
+	if s.Err != nil {
+		return GotaDataFrame{Err: fmt.Errorf("coalesce: %v", s.Err)}
+	}
+	return df.Mutate(s)
+}
+
+// SplitOption is the type used to configure the behaviour of SplitColumn.
+type SplitOption func(*splitOptions)
+
+type splitOptions struct {
+	keepRemainder bool
+}
+
+// KeepRemainder sets whether the fields beyond the last new column are kept,
+// joined by the separator, on the last new column of SplitColumn. By default
+// they are dropped.
+func KeepRemainder(b bool) SplitOption {
+	return func(c *splitOptions) {
+		c.keepRemainder = b
+	}
+}
+
+// SplitColumn splits the elements of a String column by the given separator
+// and appends a new String column for each of the given names holding the
+// resulting fields, in order. Rows with fewer fields than new columns, as well
+// as NaN rows, get NaN on the missing ones.
+func (df GotaDataFrame) SplitColumn(colname, sep string, newnames []string, options ...SplitOption) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	cfg := splitOptions{}
+	for _, option := range options {
+		option(&cfg)
+	}
+	idx := df.ColIndex(colname)
+	if idx < 0 {
+		return GotaDataFrame{Err: fmt.Errorf("split column: can't find column name %q", colname)}
+	}
+	col := df.columns[idx]
+	if col.Type() != series.String {
+		return GotaDataFrame{Err: fmt.Errorf("split column: column %q is not of type string", colname)}
+	}
+	if len(newnames) == 0 {
+		return GotaDataFrame{Err: fmt.Errorf("split column: no new column names given")}
+	}
+	for _, name := range newnames {
+		if findInStringSlice(name, df.Names()) != -1 {
+			return GotaDataFrame{Err: fmt.Errorf("split column: column name %s already exists", name)}
+		}
+	}
+
+	values := make([][]interface{}, len(newnames))
+	for j := range values {
+		values[j] = make([]interface{}, df.nrows)
+	}
+	for i := 0; i < df.nrows; i++ {
+		e := col.Elem(i)
+		if e.IsNA() {
+			continue
+		}
+		var fields []string
+		if cfg.keepRemainder {
+			fields = strings.SplitN(e.String(), sep, len(newnames))
+		} else {
+			fields = strings.Split(e.String(), sep)
+		}
+		for j := 0; j < len(fields) && j < len(newnames); j++ {
+			values[j][i] = fields[j]
+		}
+	}
+
+	columns := make([]series.Series1, 0, df.ncols+len(newnames))
+	columns = append(columns, df.columns...)
+	for j, name := range newnames {
+		columns = append(columns, series.New(values[j], series.String, name))
+	}
+	return New(columns...)
+}
+
+// ExplodeOption is the type used to configure the behaviour of Explode.
+type ExplodeOption func(*explodeOptions)
+
+type explodeOptions struct {
+	dropEmpty bool
+}
+
+// DropEmpty sets whether Explode drops the rows with an empty or NaN element
+// on the exploded column. By default they are kept as a single NaN row.
+func DropEmpty(b bool) ExplodeOption {
+	return func(c *explodeOptions) {
+		c.dropEmpty = b
+	}
+}
+
+// Explode splits the elements of a String column by the given separator and
+// returns a DataFrame with one row per resulting field, repeating the values of
+// the rest of the columns of the row.
+func (df GotaDataFrame) Explode(colname, sep string, options ...ExplodeOption) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	cfg := explodeOptions{}
+	for _, option := range options {
+		option(&cfg)
+	}
+	idx := df.ColIndex(colname)
+	if idx < 0 {
+		return GotaDataFrame{Err: fmt.Errorf("explode: can't find column name %q", colname)}
+	}
+	col := df.columns[idx]
+	if col.Type() != series.String {
+		return GotaDataFrame{Err: fmt.Errorf("explode: column %q is not of type string", colname)}
+	}
+
+	rows := make([]int, 0, df.nrows)
+	values := make([]interface{}, 0, df.nrows)
+	for i := 0; i < df.nrows; i++ {
+		e := col.Elem(i)
+		if e.IsNA() || e.String() == "" {
+			if !cfg.dropEmpty {
+				rows = append(rows, i)
+				values = append(values, nil)
+			}
+			continue
+		}
+		for _, field := range strings.Split(e.String(), sep) {
+			rows = append(rows, i)
+			values = append(values, field)
+		}
+	}
+
+	exploded := df.Subset(rows)
+	if err := exploded.Error(); err != nil {
+		return GotaDataFrame{Err: fmt.Errorf("explode: %v", err)}
+	}
+	columns := exploded.Columns()
+	columns[idx] = series.New(values, series.String, colname)
+	return New(columns...)
+}
+
+// DummiesOption is the type used to configure the behaviour of GetDummies.
+type DummiesOption func(*dummiesOptions)
+
+type dummiesOptions struct {
+	dummyNA bool
+}
+
+// DummyNA sets whether GetDummies adds an indicator column for the NaN
+// elements, named with the "NaN" value. By default NaN rows get zero on every
+// indicator.
+func DummyNA(b bool) DummiesOption {
+	return func(c *dummiesOptions) {
+		c.dummyNA = b
+	}
+}
+
+// GetDummies replaces the given column with an Int indicator column for each
+// of its distinct values, in ascending order, holding 1 on the rows with that
+// value and 0 otherwise. The indicator columns are named prefix_value, where
+// the prefix defaults to the name of the column if empty.
+func (df GotaDataFrame) GetDummies(colname string, prefix string, options ...DummiesOption) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	cfg := dummiesOptions{}
+	for _, option := range options {
+		option(&cfg)
+	}
+	idx := df.ColIndex(colname)
+	if idx < 0 {
+		return GotaDataFrame{Err: fmt.Errorf("get dummies: can't find column name %q", colname)}
+	}
+	if prefix == "" {
+		prefix = colname
+	}
+	col := df.columns[idx]
+	records := col.Records()
+	isNaN := col.IsNaN()
+
+	var levels []string
+	seen := make(map[string]int)
+	for _, i := range col.Order(false) {
+		if isNaN[i] {
+			continue
+		}
+		if _, ok := seen[records[i]]; !ok {
+			seen[records[i]] = len(levels)
+			levels = append(levels, records[i])
+		}
+	}
+	nanLevel := -1
+	if cfg.dummyNA && col.HasNaN() {
+		nanLevel = len(levels)
+		levels = append(levels, "NaN")
+	}
+
+	indicators := make([][]int, len(levels))
+	for j := range indicators {
+		indicators[j] = make([]int, df.nrows)
+	}
+	for i, r := range records {
+		switch {
+		case !isNaN[i]:
+			indicators[seen[r]][i] = 1
+		case nanLevel >= 0:
+			indicators[nanLevel][i] = 1
+		}
+	}
+
+	columns := make([]series.Series1, 0, df.ncols-1+len(levels))
+	columns = append(columns, df.columns[:idx]...)
+	for j, level := range levels {
+		columns = append(columns, series.New(indicators[j], series.Int, prefix+"_"+level))
+	}
+	columns = append(columns, df.columns[idx+1:]...)
+	return New(columns...)
+}
+
+// Reverse returns a new DataFrame with the rows of the DataFrame in reverse
+// order.
+func (df GotaDataFrame) Reverse() DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	idx := make([]int, df.nrows)
+	for i := range idx {
+		idx[i] = df.nrows - 1 - i
+	}
+	return df.Subset(idx)
+}
+
+// Astype converts the column with the given name to the given type by parsing
+// its record representation. An error is returned if any of the non NaN
+// elements of the column can't be converted, including Float elements with a
+// fractional part when converting to Int.
+func (df GotaDataFrame) Astype(colname string, t series.Type) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	idx := df.ColIndex(colname)
+	if idx < 0 {
+		return GotaDataFrame{Err: fmt.Errorf("astype: unknown column name %s", colname)}
+	}
+	col := df.columns[idx]
+	isNaN := col.IsNaN()
+
+	var values interface{} = col.Records()
+	if t == series.Int && col.Type() == series.Float {
+		ints := make([]interface{}, col.Len())
+		for i, f := range col.Float() {
+			if isNaN[i] {
+				continue
+			}
+			if f != math.Trunc(f) {
+				return GotaDataFrame{Err: fmt.Errorf("astype: can't convert %v on row %d to %s", f, i, t)}
+			}
+			ints[i] = int(f)
+		}
+		values = ints
+	}
+	s := series.New(values, t, col.Name)
+	if s.Err != nil {
+		return GotaDataFrame{Err: fmt.Errorf("astype: %v", s.Err)}
+	}
+	for i, nan := range s.IsNaN() {
+		if nan && !isNaN[i] {
+			return GotaDataFrame{Err: fmt.Errorf("astype: can't convert %q on row %d to %s", col.Elem(i).String(), i, t)}
+		}
+	}
+
+	columns := make([]series.Series1, df.ncols)
+	copy(columns, df.columns)
+	columns[idx] = s
+	return New(columns...)
+}
+
+// RowNumber appends an Int column with the given name containing the 0-based
+// index of every row, or the 1-based index if oneBased is set. The name of the
+// new column can't already exist on the DataFrame.
+func (df GotaDataFrame) RowNumber(name string, oneBased ...bool) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	if findInStringSlice(name, df.Names()) != -1 {
+		return GotaDataFrame{Err: fmt.Errorf("row number: column name %s already exists", name)}
+	}
+	start := 0
+	if len(oneBased) > 0 && oneBased[0] {
+		start = 1
+	}
+	rows := make([]int, df.nrows)
+	for i := range rows {
+		rows[i] = start + i
+	}
+	columns := make([]series.Series1, 0, df.ncols+1)
+	columns = append(columns, df.columns...)
+	columns = append(columns, series.New(rows, series.Int, name))
+	return New(columns...)
+}
+
 // Filter will filter the rows of a DataFrame based on the given filters. All
 // filters on the argument of a Filter call are aggregated as an OR operation
 // whereas if we chain Filter calls, every filter will act as an AND operation
@@ -548,11 +1247,16 @@ func (df GotaDataFrame) Filter(filters ...F) DataFrame {
 
 // FilterAggregation will filter the rows of a DataFrame based on the given filters. All
 // filters on the argument of a Filter call are aggregated depending on the supplied
-// aggregation.
+// aggregation, from left to right. An error is returned for unknown aggregations.
 func (df GotaDataFrame) FilterAggregation(agg Aggregation, filters ...F) DataFrame {
 	if df.Err != nil {
 		return df
 	}
+	switch agg {
+	case Or, And, AndNot, Xor:
+	default:
+		return GotaDataFrame{Err: fmt.Errorf("filter: %v", agg)}
+	}
 
 	compResults := make([]series.Series1, len(filters))
 	for i, f := range filters {
@@ -591,14 +1295,32 @@ func (df GotaDataFrame) FilterAggregation(agg Aggregation, filters ...F) DataFra
 				res[j] = res[j] || nextRes[j]
 			case And:
 				res[j] = res[j] && nextRes[j]
-			default:
-				panic(agg)
+			case AndNot:
+				res[j] = res[j] && !nextRes[j]
+			case Xor:
+				res[j] = res[j] != nextRes[j]
 			}
 		}
 	}
 	return df.Subset(res)
 }
 
+// FilterByFunc will filter the rows of a DataFrame keeping those for which the
+// given predicate returns true. The predicate receives the map representation
+// of every row, as returned by Maps, so it can express conditions involving
+// several columns. Since a map is built for every row, this is considerably
+// slower than Filter, which should be preferred for per-column comparisons.
+func (df GotaDataFrame) FilterByFunc(f func(row map[string]interface{}) bool) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	res := make([]bool, df.nrows)
+	for i, row := range df.Rows() {
+		res[i] = f(row)
+	}
+	return df.Subset(res)
+}
+
 // Arrange sort the rows of a DataFrame according to the given Order
 func (df GotaDataFrame) Arrange(order ...Order) DataFrame {
 	if df.Err != nil {
@@ -636,12 +1358,34 @@ func (df GotaDataFrame) Arrange(order ...Order) DataFrame {
 		colname := order[i].Colname
 		idx := df.ColIndex(colname)
 		nextSeries := df.columns[idx].Subset(suborder)
-		suborder = nextSeries.Order(order[i].Reverse)
+		if order[i].Numeric {
+			suborder = numericOrder(nextSeries, order[i].Reverse)
+		} else {
+			suborder = nextSeries.Order(order[i].Reverse)
+		}
 		swapOrigIdx(suborder)
 	}
 	return df.Subset(origIdx)
 }
 
+// Pipe passes the DataFrame through the given functions in order, each of them
+// receiving the result of the previous one. If any function returns a
+// DataFrame with errors, the remaining ones are not called and an error
+// identifying the failing stage is returned.
+func (df GotaDataFrame) Pipe(fns ...func(DataFrame) DataFrame) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	var ret DataFrame = df
+	for i, fn := range fns {
+		ret = fn(ret)
+		if err := ret.Error(); err != nil {
+			return GotaDataFrame{Err: fmt.Errorf("pipe: stage %d: %v", i, err)}
+		}
+	}
+	return ret
+}
+
 // CApply applies the given function to the columns of a DataFrame
 func (df GotaDataFrame) CApply(f func(series.Series1) series.Series1) DataFrame {
 	if df.Err != nil {
@@ -656,25 +1400,70 @@ func (df GotaDataFrame) CApply(f func(series.Series1) series.Series1) DataFrame
 	return New(columns...)
 }
 
+// CApplyParallel applies the given function to the columns of a DataFrame
+// like CApply, but distributes the columns among the given number of worker
+// goroutines. If workers is not positive, runtime.NumCPU workers are used. The
+// columns keep their original order and, if the function returns Series with
+// errors, the error of the leftmost of them is returned.
+func (df GotaDataFrame) CApplyParallel(f func(series.Series1) series.Series1, workers int) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	columns := make([]series.Series1, df.ncols)
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				applied := f(df.columns[i])
+				applied.Name = df.columns[i].Name
+				columns[i] = applied
+			}
+		}()
+	}
+	for i := range df.columns {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, s := range columns {
+		if s.Err != nil {
+			return GotaDataFrame{Err: fmt.Errorf("capply parallel: column %s has errors: %v", s.Name, s.Err)}
+		}
+	}
+	return New(columns...)
+}
+
 // RApply applies the given function to the rows of a DataFrame. Prior to applying
 // the function the elements of each row are cast to a Series of a specific
-// type. In order of priority: String -> Float -> Int -> Bool. This casting also
-// takes place after the function application to equalize the type of the columns.
+// type. In order of priority: String -> Float -> Int -> Bool. Rows mixing Uint
+// and Int elements are cast to Float, as neither type can hold the other, and
+// Time elements are cast to String. This casting also takes place after the
+// function application to equalize the type of the columns.
 func (df GotaDataFrame) RApply(f func(series.Series1) series.Series1) DataFrame {
 	if df.Err != nil {
 		return df
 	}
 
 	detectType := func(types []series.Type) series.Type {
-		var hasStrings, hasFloats, hasInts, hasBools bool
+		var hasStrings, hasFloats, hasInts, hasUints, hasBools bool
 		for _, t := range types {
 			switch t {
-			case series.String:
+			case series.String, series.Time:
 				hasStrings = true
 			case series.Float:
 				hasFloats = true
 			case series.Int:
 				hasInts = true
+			case series.Uint:
+				hasUints = true
 			case series.Bool:
 				hasBools = true
 			}
@@ -684,8 +1473,10 @@ func (df GotaDataFrame) RApply(f func(series.Series1) series.Series1) DataFrame
 			return series.String
 		case hasBools:
 			return series.Bool
-		case hasFloats:
+		case hasFloats, hasUints && hasInts:
 			return series.Float
+		case hasUints:
+			return series.Uint
 		case hasInts:
 			return series.Int
 		default:
@@ -805,6 +1596,46 @@ func (df GotaDataFrame) NCol() int {
 	return df.ncols
 }
 
+// MemoryUsageTotal is the key under which MemoryUsage reports the total
+// estimate. Since column names are never empty it can't collide with them.
+const MemoryUsageTotal = ""
+
+// MemoryUsage returns an estimate of the bytes used by the values of each column
+// of the DataFrame, indexed by column name, along with their total under the
+// MemoryUsageTotal key. Numeric and Bool columns are estimated from the size of
+// their values and String columns from the size of a string header plus the
+// length of each string. The overhead of the Series structures is not included.
+func (df GotaDataFrame) MemoryUsage() map[string]int {
+	usage := make(map[string]int, df.ncols+1)
+	if df.Err != nil {
+		return usage
+	}
+	total := 0
+	for _, col := range df.columns {
+		var bytes int
+		switch col.Type() {
+		case series.String:
+			for _, r := range col.Records() {
+				bytes += int(unsafe.Sizeof("")) + len(r)
+			}
+		case series.Bool:
+			bytes = col.Len() * int(unsafe.Sizeof(false))
+		case series.Float:
+			bytes = col.Len() * int(unsafe.Sizeof(float64(0)))
+		case series.Uint:
+			bytes = col.Len() * int(unsafe.Sizeof(uint(0)))
+		case series.Time:
+			bytes = col.Len() * int(unsafe.Sizeof(time.Time{}))
+		default:
+			bytes = col.Len() * int(unsafe.Sizeof(int(0)))
+		}
+		usage[col.Name] = bytes
+		total += bytes
+	}
+	usage[MemoryUsageTotal] = total
+	return usage
+}
+
 // Col returns a copy of the Series with the given column name contained in the DataFrame.
 func (df GotaDataFrame) Col(colname string) series.Series1 {
 	if df.Err != nil {
@@ -818,29 +1649,61 @@ func (df GotaDataFrame) Col(colname string) series.Series1 {
 	return df.columns[idx].Copy()
 }
 
-// InnerJoin returns a DataFrame containing the inner join of two DataFrames.
-func (df GotaDataFrame) InnerJoin(b DataFrame, keys ...string) DataFrame {
-	if len(keys) == 0 {
-		return GotaDataFrame{Err: fmt.Errorf("join keys not specified")}
+// joinKeyIndexes returns the column indexes of the given join keys on both
+// DataFrames.
+func joinKeyIndexes(a, b DataFrame, leftKeys, rightKeys []string) ([]int, []int, error) {
+	if len(leftKeys) == 0 || len(rightKeys) == 0 {
+		return nil, nil, fmt.Errorf("join keys not specified")
+	}
+	if len(leftKeys) != len(rightKeys) {
+		return nil, nil, fmt.Errorf("different number of join keys on left and right DataFrames")
 	}
 	// Check that we have all given keys in both DataFrames
 	var iKeysA []int
 	var iKeysB []int
 	var errorArr []string
-	for _, key := range keys {
-		i := df.ColIndex(key)
+	for k := range leftKeys {
+		i := a.ColIndex(leftKeys[k])
 		if i < 0 {
-			errorArr = append(errorArr, fmt.Sprintf("can't find key %q on left DataFrame", key))
+			errorArr = append(errorArr, fmt.Sprintf("can't find key %q on left DataFrame", leftKeys[k]))
 		}
 		iKeysA = append(iKeysA, i)
-		j := b.ColIndex(key)
+		j := b.ColIndex(rightKeys[k])
 		if j < 0 {
-			errorArr = append(errorArr, fmt.Sprintf("can't find key %q on right DataFrame", key))
+			errorArr = append(errorArr, fmt.Sprintf("can't find key %q on right DataFrame", rightKeys[k]))
 		}
 		iKeysB = append(iKeysB, j)
 	}
 	if len(errorArr) != 0 {
-		return GotaDataFrame{Err: fmt.Errorf(strings.Join(errorArr, "\n"))}
+		return nil, nil, fmt.Errorf(strings.Join(errorArr, "\n"))
+	}
+	return iKeysA, iKeysB, nil
+}
+
+// InnerJoin returns a DataFrame containing the inner join of two DataFrames.
+func (df GotaDataFrame) InnerJoin(b DataFrame, keys ...string) DataFrame {
+	return df.InnerJoinOn(b, keys, keys)
+}
+
+// InnerJoinOn returns a DataFrame containing the inner join of two DataFrames, matching
+// the leftKeys columns of the DataFrame with the rightKeys columns of b. The
+// key columns of the result keep the names of the leftKeys. Non-key columns
+// present on both DataFrames can be disambiguated with the Suffixes option.
+func (df GotaDataFrame) InnerJoinOn(b DataFrame, leftKeys, rightKeys []string, options ...JoinOption) DataFrame {
+	return df.innerJoin(context.Background(), b, leftKeys, rightKeys, options)
+}
+
+// InnerJoinCtx is like InnerJoin, but stops the join as soon as possible if
+// the given context is done, returning the error of the context.
+func (df GotaDataFrame) InnerJoinCtx(ctx context.Context, b DataFrame, keys ...string) (DataFrame, error) {
+	ret := df.innerJoin(ctx, b, keys, keys, nil)
+	return ret, ret.Error()
+}
+
+func (df GotaDataFrame) innerJoin(ctx context.Context, b DataFrame, leftKeys, rightKeys []string, options []JoinOption) DataFrame {
+	iKeysA, iKeysB, err := joinKeyIndexes(df, b, leftKeys, rightKeys)
+	if err != nil {
+		return GotaDataFrame{Err: err}
 	}
 
 	aCols := df.columns
@@ -850,76 +1713,243 @@ func (df GotaDataFrame) InnerJoin(b DataFrame, keys ...string) DataFrame {
 	for _, i := range iKeysA {
 		newCols = append(newCols, aCols[i].Empty())
 	}
-	var iNotKeysA []int
-	for i := 0; i < df.ncols; i++ {
-		if !inIntSlice(i, iKeysA) {
-			iNotKeysA = append(iNotKeysA, i)
-			newCols = append(newCols, aCols[i].Empty())
+	var iNotKeysA []int
+	for i := 0; i < df.ncols; i++ {
+		if !inIntSlice(i, iKeysA) {
+			iNotKeysA = append(iNotKeysA, i)
+			newCols = append(newCols, aCols[i].Empty())
+		}
+	}
+	var iNotKeysB []int
+	for i := 0; i < b.NCol(); i++ {
+		if !inIntSlice(i, iKeysB) {
+			iNotKeysB = append(iNotKeysB, i)
+			newCols = append(newCols, bCols[i].Empty())
+		}
+	}
+	for i := range newCols {
+		newCols[i].Grow(df.nrows)
+	}
+
+	// Fill newCols
+	var pairs []joinPair
+	if hashableJoinKeys(aCols, bCols, iKeysA, iKeysB) {
+		pairs, err = hashJoinPairs(ctx, aCols, bCols, iKeysA, iKeysB, df.nrows, b.NRow())
+	} else {
+		pairs, err = nestedLoopJoinPairs(ctx, aCols, bCols, iKeysA, iKeysB, df.nrows, b.NRow())
+	}
+	if err != nil {
+		return GotaDataFrame{Err: err}
+	}
+	for n, p := range pairs {
+		if n%ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return GotaDataFrame{Err: err}
+			}
+		}
+		ii := 0
+		for _, k := range iKeysA {
+			elem := aCols[k].Elem(p.i)
+			newCols[ii].Append(elem)
+			ii++
+		}
+		for _, k := range iNotKeysA {
+			elem := aCols[k].Elem(p.i)
+			newCols[ii].Append(elem)
+			ii++
+		}
+		for _, k := range iNotKeysB {
+			elem := bCols[k].Elem(p.j)
+			newCols[ii].Append(elem)
+			ii++
+		}
+	}
+	applyJoinSuffixes(newCols, len(iKeysA), len(iNotKeysA), options)
+	return New(newCols...)
+}
+
+// JoinOption is the type used to configure joins.
+type JoinOption func(*joinOptions)
+
+type joinOptions struct {
+	// If set, the suffixes appended to the names of overlapping columns.
+	suffixes    bool
+	leftSuffix  string
+	rightSuffix string
+}
+
+// Suffixes sets the suffixes appended to the names of the columns of a join
+// result that have the same name on both DataFrames, such as "_x" and "_y".
+// The key columns of the left DataFrame keep their names. Without this option,
+// duplicated names are deduplicated as in New.
+func Suffixes(left, right string) JoinOption {
+	return func(c *joinOptions) {
+		c.suffixes = true
+		c.leftSuffix = left
+		c.rightSuffix = right
+	}
+}
+
+// applyJoinSuffixes renames the overlapping columns of a join result according
+// to the given options. The result columns are the nKeys key columns, followed
+// by the nLeft non-key columns of the left DataFrame and the non-key columns of
+// the right DataFrame.
+func applyJoinSuffixes(cols []series.Series1, nKeys, nLeft int, options []JoinOption) {
+	cfg := joinOptions{}
+	for _, option := range options {
+		option(&cfg)
+	}
+	if !cfg.suffixes {
+		return
+	}
+	leftNames := make(map[string]bool)
+	for _, col := range cols[:nKeys+nLeft] {
+		leftNames[col.Name] = true
+	}
+	rightNames := make(map[string]bool)
+	for _, col := range cols[nKeys+nLeft:] {
+		rightNames[col.Name] = true
+	}
+	for i := nKeys; i < nKeys+nLeft; i++ {
+		if rightNames[cols[i].Name] {
+			cols[i].Name += cfg.leftSuffix
 		}
 	}
-	var iNotKeysB []int
-	for i := 0; i < b.NCol(); i++ {
-		if !inIntSlice(i, iKeysB) {
-			iNotKeysB = append(iNotKeysB, i)
-			newCols = append(newCols, bCols[i].Empty())
+	for i := nKeys + nLeft; i < len(cols); i++ {
+		if leftNames[cols[i].Name] {
+			cols[i].Name += cfg.rightSuffix
 		}
 	}
+}
 
-	// Fill newCols
-	for i := 0; i < df.nrows; i++ {
-		for j := 0; j < b.NRow(); j++ {
+// joinPair is a pair of matching rows of the left and right DataFrames of a
+// join.
+type joinPair struct {
+	i, j int
+}
+
+// ctxCheckInterval is the number of rows processed by the context aware
+// operations between checks of their context.
+const ctxCheckInterval = 1024
+
+// nestedLoopJoinPairs returns the pairs of matching rows of two DataFrames by
+// comparing every row of the left DataFrame with every row of the right one.
+// The error of the context is returned if it is done before finishing.
+func nestedLoopJoinPairs(ctx context.Context, aCols, bCols []series.Series1, iKeysA, iKeysB []int, aRows, bRows int) ([]joinPair, error) {
+	var pairs []joinPair
+	for i := 0; i < aRows; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		for j := 0; j < bRows; j++ {
 			match := true
-			for k := range keys {
+			for k := range iKeysA {
 				aElem := aCols[iKeysA[k]].Elem(i)
 				bElem := bCols[iKeysB[k]].Elem(j)
 				match = match && aElem.Eq(bElem)
 			}
 			if match {
-				ii := 0
-				for _, k := range iKeysA {
-					elem := aCols[k].Elem(i)
-					newCols[ii].Append(elem)
-					ii++
-				}
-				for _, k := range iNotKeysA {
-					elem := aCols[k].Elem(i)
-					newCols[ii].Append(elem)
-					ii++
-				}
-				for _, k := range iNotKeysB {
-					elem := bCols[k].Elem(j)
-					newCols[ii].Append(elem)
-					ii++
-				}
+				pairs = append(pairs, joinPair{i, j})
 			}
 		}
 	}
-	return New(newCols...)
+	return pairs, nil
 }
 
-// LeftJoin returns a DataFrame containing the left join of two DataFrames.
-func (df GotaDataFrame) LeftJoin(b DataFrame, keys ...string) DataFrame {
-	if len(keys) == 0 {
-		return GotaDataFrame{Err: fmt.Errorf("join keys not specified")}
+// hashJoinPairs returns the same pairs of matching rows as nestedLoopJoinPairs
+// and in the same order, but indexing the rows of the right DataFrame by the
+// value of their keys first, so that every row of the left DataFrame is
+// matched in constant time. Rows with NaN keys never match.
+func hashJoinPairs(ctx context.Context, aCols, bCols []series.Series1, iKeysA, iKeysB []int, aRows, bRows int) ([]joinPair, error) {
+	index := make(map[string][]int, bRows)
+	for j := 0; j < bRows; j++ {
+		if j%ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		if key, ok := joinKey(bCols, iKeysB, j); ok {
+			index[key] = append(index[key], j)
+		}
 	}
-	// Check that we have all given keys in both DataFrames
-	var iKeysA []int
-	var iKeysB []int
-	var errorArr []string
-	for _, key := range keys {
-		i := df.ColIndex(key)
-		if i < 0 {
-			errorArr = append(errorArr, fmt.Sprintf("can't find key %q on left DataFrame", key))
+	var pairs []joinPair
+	for i := 0; i < aRows; i++ {
+		if i%ctxCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
 		}
-		iKeysA = append(iKeysA, i)
-		j := b.ColIndex(key)
-		if j < 0 {
-			errorArr = append(errorArr, fmt.Sprintf("can't find key %q on right DataFrame", key))
+		key, ok := joinKey(aCols, iKeysA, i)
+		if !ok {
+			continue
+		}
+		for _, j := range index[key] {
+			pairs = append(pairs, joinPair{i, j})
 		}
-		iKeysB = append(iKeysB, j)
 	}
-	if len(errorArr) != 0 {
-		return GotaDataFrame{Err: fmt.Errorf(strings.Join(errorArr, "\n"))}
+	return pairs, nil
+}
+
+// hashableJoinKeys reports whether the given keys can be matched by hashJoinPairs,
+// which requires the key columns on both sides to have the same type, since
+// otherwise elements are compared after converting them to the type of the
+// left column.
+func hashableJoinKeys(aCols, bCols []series.Series1, iKeysA, iKeysB []int) bool {
+	for k := range iKeysA {
+		t := aCols[iKeysA[k]].Type()
+		if t != bCols[iKeysB[k]].Type() {
+			return false
+		}
+		switch t {
+		case series.String, series.Int, series.Uint, series.Float, series.Bool:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// joinKey returns the string encoding of the values of the given key columns on
+// a row, or false if any of them is NaN. Each value is prefixed by its length
+// so that different combinations of values can't have the same encoding.
+func joinKey(cols []series.Series1, iKeys []int, row int) (string, bool) {
+	var sb strings.Builder
+	for _, k := range iKeys {
+		e := cols[k].Elem(row)
+		if e.IsNA() {
+			return "", false
+		}
+		var v string
+		if e.Type() == series.Float {
+			f := e.Float()
+			if f == 0 {
+				// Normalize negative zero, which is equal to zero
+				f = 0
+			}
+			v = strconv.FormatFloat(f, 'g', -1, 64)
+		} else {
+			v = e.String()
+		}
+		sb.WriteString(strconv.Itoa(len(v)))
+		sb.WriteByte(':')
+		sb.WriteString(v)
+	}
+	return sb.String(), true
+}
+
+// LeftJoin returns a DataFrame containing the left join of two DataFrames.
+func (df GotaDataFrame) LeftJoin(b DataFrame, keys ...string) DataFrame {
+	return df.LeftJoinOn(b, keys, keys)
+}
+
+// LeftJoinOn returns a DataFrame containing the left join of two DataFrames, matching
+// the leftKeys columns of the DataFrame with the rightKeys columns of b. The
+// key columns of the result keep the names of the leftKeys. Non-key columns
+// present on both DataFrames can be disambiguated with the Suffixes option.
+func (df GotaDataFrame) LeftJoinOn(b DataFrame, leftKeys, rightKeys []string, options ...JoinOption) DataFrame {
+	iKeysA, iKeysB, err := joinKeyIndexes(df, b, leftKeys, rightKeys)
+	if err != nil {
+		return GotaDataFrame{Err: err}
 	}
 
 	aCols := df.columns
@@ -943,13 +1973,16 @@ func (df GotaDataFrame) LeftJoin(b DataFrame, keys ...string) DataFrame {
 			newCols = append(newCols, bCols[i].Empty())
 		}
 	}
+	for i := range newCols {
+		newCols[i].Grow(df.nrows)
+	}
 
 	// Fill newCols
 	for i := 0; i < df.nrows; i++ {
 		matched := false
 		for j := 0; j < b.NRow(); j++ {
 			match := true
-			for k := range keys {
+			for k := range iKeysA {
 				aElem := aCols[iKeysA[k]].Elem(i)
 				bElem := bCols[iKeysB[k]].Elem(j)
 				match = match && aElem.Eq(bElem)
@@ -987,37 +2020,28 @@ func (df GotaDataFrame) LeftJoin(b DataFrame, keys ...string) DataFrame {
 				ii++
 			}
 			for range iNotKeysB {
-				newCols[ii].Append(nil)
+				newCols[ii].AppendNA()
 				ii++
 			}
 		}
 	}
+	applyJoinSuffixes(newCols, len(iKeysA), len(iNotKeysA), options)
 	return New(newCols...)
 }
 
 // RightJoin returns a DataFrame containing the right join of two DataFrames.
 func (df GotaDataFrame) RightJoin(b DataFrame, keys ...string) DataFrame {
-	if len(keys) == 0 {
-		return GotaDataFrame{Err: fmt.Errorf("join keys not specified")}
-	}
-	// Check that we have all given keys in both DataFrames
-	var iKeysA []int
-	var iKeysB []int
-	var errorArr []string
-	for _, key := range keys {
-		i := df.ColIndex(key)
-		if i < 0 {
-			errorArr = append(errorArr, fmt.Sprintf("can't find key %q on left DataFrame", key))
-		}
-		iKeysA = append(iKeysA, i)
-		j := b.ColIndex(key)
-		if j < 0 {
-			errorArr = append(errorArr, fmt.Sprintf("can't find key %q on right DataFrame", key))
-		}
-		iKeysB = append(iKeysB, j)
-	}
-	if len(errorArr) != 0 {
-		return GotaDataFrame{Err: fmt.Errorf(strings.Join(errorArr, "\n"))}
+	return df.RightJoinOn(b, keys, keys)
+}
+
+// RightJoinOn returns a DataFrame containing the right join of two DataFrames, matching
+// the leftKeys columns of the DataFrame with the rightKeys columns of b. The
+// key columns of the result keep the names of the leftKeys. Non-key columns
+// present on both DataFrames can be disambiguated with the Suffixes option.
+func (df GotaDataFrame) RightJoinOn(b DataFrame, leftKeys, rightKeys []string, options ...JoinOption) DataFrame {
+	iKeysA, iKeysB, err := joinKeyIndexes(df, b, leftKeys, rightKeys)
+	if err != nil {
+		return GotaDataFrame{Err: err}
 	}
 
 	aCols := df.columns
@@ -1041,6 +2065,9 @@ func (df GotaDataFrame) RightJoin(b DataFrame, keys ...string) DataFrame {
 			newCols = append(newCols, bCols[i].Empty())
 		}
 	}
+	for i := range newCols {
+		newCols[i].Grow(b.NRow())
+	}
 
 	// Fill newCols
 	var yesmatched []struct{ i, j int }
@@ -1049,7 +2076,7 @@ func (df GotaDataFrame) RightJoin(b DataFrame, keys ...string) DataFrame {
 		matched := false
 		for i := 0; i < df.nrows; i++ {
 			match := true
-			for k := range keys {
+			for k := range iKeysA {
 				aElem := aCols[iKeysA[k]].Elem(i)
 				bElem := bCols[iKeysB[k]].Elem(j)
 				match = match && aElem.Eq(bElem)
@@ -1091,7 +2118,7 @@ func (df GotaDataFrame) RightJoin(b DataFrame, keys ...string) DataFrame {
 			ii++
 		}
 		for range iNotKeysA {
-			newCols[ii].Append(nil)
+			newCols[ii].AppendNA()
 			ii++
 		}
 		for _, k := range iNotKeysB {
@@ -1100,32 +2127,23 @@ func (df GotaDataFrame) RightJoin(b DataFrame, keys ...string) DataFrame {
 			ii++
 		}
 	}
+	applyJoinSuffixes(newCols, len(iKeysA), len(iNotKeysA), options)
 	return New(newCols...)
 }
 
 // OuterJoin returns a DataFrame containing the outer join of two DataFrames.
 func (df GotaDataFrame) OuterJoin(b DataFrame, keys ...string) DataFrame {
-	if len(keys) == 0 {
-		return GotaDataFrame{Err: fmt.Errorf("join keys not specified")}
-	}
-	// Check that we have all given keys in both DataFrames
-	var iKeysA []int
-	var iKeysB []int
-	var errorArr []string
-	for _, key := range keys {
-		i := df.ColIndex(key)
-		if i < 0 {
-			errorArr = append(errorArr, fmt.Sprintf("can't find key %q on left DataFrame", key))
-		}
-		iKeysA = append(iKeysA, i)
-		j := b.ColIndex(key)
-		if j < 0 {
-			errorArr = append(errorArr, fmt.Sprintf("can't find key %q on right DataFrame", key))
-		}
-		iKeysB = append(iKeysB, j)
-	}
-	if len(errorArr) != 0 {
-		return GotaDataFrame{Err: fmt.Errorf(strings.Join(errorArr, "\n"))}
+	return df.OuterJoinOn(b, keys, keys)
+}
+
+// OuterJoinOn returns a DataFrame containing the outer join of two DataFrames, matching
+// the leftKeys columns of the DataFrame with the rightKeys columns of b. The
+// key columns of the result keep the names of the leftKeys. Non-key columns
+// present on both DataFrames can be disambiguated with the Suffixes option.
+func (df GotaDataFrame) OuterJoinOn(b DataFrame, leftKeys, rightKeys []string, options ...JoinOption) DataFrame {
+	iKeysA, iKeysB, err := joinKeyIndexes(df, b, leftKeys, rightKeys)
+	if err != nil {
+		return GotaDataFrame{Err: err}
 	}
 
 	aCols := df.columns
@@ -1149,13 +2167,16 @@ func (df GotaDataFrame) OuterJoin(b DataFrame, keys ...string) DataFrame {
 			newCols = append(newCols, bCols[i].Empty())
 		}
 	}
+	for i := range newCols {
+		newCols[i].Grow(df.nrows + b.NRow())
+	}
 
 	// Fill newCols
 	for i := 0; i < df.nrows; i++ {
 		matched := false
 		for j := 0; j < b.NRow(); j++ {
 			match := true
-			for k := range keys {
+			for k := range iKeysA {
 				aElem := aCols[iKeysA[k]].Elem(i)
 				bElem := bCols[iKeysB[k]].Elem(j)
 				match = match && aElem.Eq(bElem)
@@ -1193,7 +2214,7 @@ func (df GotaDataFrame) OuterJoin(b DataFrame, keys ...string) DataFrame {
 				ii++
 			}
 			for range iNotKeysB {
-				newCols[ii].Append(nil)
+				newCols[ii].AppendNA()
 				ii++
 			}
 		}
@@ -1202,7 +2223,7 @@ func (df GotaDataFrame) OuterJoin(b DataFrame, keys ...string) DataFrame {
 		matched := false
 		for i := 0; i < df.nrows; i++ {
 			match := true
-			for k := range keys {
+			for k := range iKeysA {
 				aElem := aCols[iKeysA[k]].Elem(i)
 				bElem := bCols[iKeysB[k]].Elem(j)
 				match = match && aElem.Eq(bElem)
@@ -1219,7 +2240,7 @@ func (df GotaDataFrame) OuterJoin(b DataFrame, keys ...string) DataFrame {
 				ii++
 			}
 			for range iNotKeysA {
-				newCols[ii].Append(nil)
+				newCols[ii].AppendNA()
 				ii++
 			}
 			for _, k := range iNotKeysB {
@@ -1229,6 +2250,7 @@ func (df GotaDataFrame) OuterJoin(b DataFrame, keys ...string) DataFrame {
 			}
 		}
 	}
+	applyJoinSuffixes(newCols, len(iKeysA), len(iNotKeysA), options)
 	return New(newCols...)
 }
 
@@ -1244,6 +2266,9 @@ func (df GotaDataFrame) CrossJoin(b DataFrame) DataFrame {
 	for i := 0; i < b.NCol(); i++ {
 		newCols = append(newCols, bCols[i].Empty())
 	}
+	for i := range newCols {
+		newCols[i].Grow(df.nrows * b.NRow())
+	}
 	// Fill newCols
 	for i := 0; i < df.nrows; i++ {
 		for j := 0; j < b.NRow(); j++ {
@@ -1261,6 +2286,60 @@ func (df GotaDataFrame) CrossJoin(b DataFrame) DataFrame {
 	return New(newCols...)
 }
 
+// SemiJoin returns the rows of the DataFrame that have a match on b for the
+// given keys. No columns from b are added to the result.
+func (df GotaDataFrame) SemiJoin(b DataFrame, keys ...string) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	matched, err := df.matchedRows(b, keys)
+	if err != nil {
+		return GotaDataFrame{Err: err}
+	}
+	return df.Subset(matched)
+}
+
+// AntiJoin returns the rows of the DataFrame that don't have a match on b for
+// the given keys. No columns from b are added to the result.
+func (df GotaDataFrame) AntiJoin(b DataFrame, keys ...string) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	matched, err := df.matchedRows(b, keys)
+	if err != nil {
+		return GotaDataFrame{Err: err}
+	}
+	for i := range matched {
+		matched[i] = !matched[i]
+	}
+	return df.Subset(matched)
+}
+
+// matchedRows returns which of the rows of the DataFrame have at least one
+// matching row on b for the given keys.
+func (df GotaDataFrame) matchedRows(b DataFrame, keys []string) ([]bool, error) {
+	iKeysA, iKeysB, err := joinKeyIndexes(df, b, keys, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	aCols := df.columns
+	bCols := b.Columns()
+	matched := make([]bool, df.nrows)
+	for i := 0; i < df.nrows; i++ {
+		for j := 0; j < b.NRow() && !matched[i]; j++ {
+			match := true
+			for k := range iKeysA {
+				aElem := aCols[iKeysA[k]].Elem(i)
+				bElem := bCols[iKeysB[k]].Elem(j)
+				match = match && aElem.Eq(bElem)
+			}
+			matched[i] = match
+		}
+	}
+	return matched, nil
+}
+
 // colIndex returns the index of the column with name `s`. If it fails to find the
 // column it returns -1 instead.
 func (df GotaDataFrame) ColIndex(s string) int {
@@ -1272,6 +2351,91 @@ func (df GotaDataFrame) ColIndex(s string) int {
 	return -1
 }
 
+// Equal reports whether two DataFrames have the same dimensions, column names,
+// column types and element values, where NaN elements are considered equal to
+// each other. DataFrames with errors are never equal.
+func (df GotaDataFrame) Equal(other DataFrame) bool {
+	return df.equal(other, 0)
+}
+
+// EqualApprox is like Equal, but the elements of Float columns are considered
+// equal if they differ by at most tol.
+func (df GotaDataFrame) EqualApprox(other DataFrame, tol float64) bool {
+	return df.equal(other, tol)
+}
+
+func (df GotaDataFrame) equal(other DataFrame, tol float64) bool {
+	if df.Err != nil || other.Error() != nil {
+		return false
+	}
+	if nrows, ncols := other.Dims(); nrows != df.nrows || ncols != df.ncols {
+		return false
+	}
+	otherCols := other.Columns()
+	for j, a := range df.columns {
+		b := otherCols[j]
+		if a.Name != b.Name || a.Type() != b.Type() {
+			return false
+		}
+		aNaN, bNaN := a.IsNaN(), b.IsNaN()
+		var aFloats, bFloats []float64
+		if a.Type() == series.Float {
+			aFloats, bFloats = a.Float(), b.Float()
+		}
+		for i := 0; i < df.nrows; i++ {
+			switch {
+			case aNaN[i] || bNaN[i]:
+				if aNaN[i] != bNaN[i] {
+					return false
+				}
+			case aFloats != nil:
+				if math.Abs(aFloats[i]-bFloats[i]) > tol {
+					return false
+				}
+			case a.Elem(i).String() != b.Elem(i).String():
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Hash returns a fingerprint of the contents of the DataFrame, computed with the
+// 64-bit FNV-1a hash over its column names, column types and element values in
+// order. It is reproducible across runs, so it can be used to detect changes
+// on the data between them. The hash of a DataFrame with errors is zero.
+func (df GotaDataFrame) Hash() uint64 {
+	if df.Err != nil {
+		return 0
+	}
+	h := fnv.New64a()
+	write := func(s string) {
+		h.Write([]byte(strconv.Itoa(len(s))))
+		h.Write([]byte{':'})
+		h.Write([]byte(s))
+	}
+	write(strconv.Itoa(df.nrows))
+	write(strconv.Itoa(df.ncols))
+	for _, col := range df.columns {
+		write(col.Name)
+		write(string(col.Type()))
+		for i := 0; i < df.nrows; i++ {
+			e := col.Elem(i)
+			switch {
+			case e.IsNA():
+				// Lengths are always written first, so this can't collide
+				// with a value
+				h.Write([]byte{'-'})
+			case col.Type() == series.Float:
+				write(strconv.FormatFloat(e.Float(), 'g', -1, 64))
+			default:
+				write(e.String())
+			}
+		}
+	}
+	return h.Sum64()
+}
+
 // Records return the string record representation of a DataFrame.
 func (df GotaDataFrame) Records() [][]string {
 	var records [][]string
@@ -1302,24 +2466,158 @@ func (df GotaDataFrame) Maps() []map[string]interface{} {
 	return maps
 }
 
+// Rows returns an iterator over the rows of the DataFrame, yielding the index of
+// every row along with its map representation, as returned by Maps. Rows are
+// built lazily, so iteration can be stopped early without materializing the
+// rest of the DataFrame.
+func (df GotaDataFrame) Rows() func(yield func(int, map[string]interface{}) bool) {
+	return func(yield func(int, map[string]interface{}) bool) {
+		if df.Err != nil {
+			return
+		}
+		colnames := df.Names()
+		for i := 0; i < df.nrows; i++ {
+			m := make(map[string]interface{}, df.ncols)
+			for k, v := range colnames {
+				m[v] = df.columns[k].Val(i)
+			}
+			if !yield(i, m) {
+				return
+			}
+		}
+	}
+}
+
 // Elem returns the element on row `r` and column `c`. Will panic if the index is
 // out of bounds.
 func (df GotaDataFrame) Elem(r, c int) series.Element {
 	return df.columns[c].Elem(r)
 }
 
-// Describe prints the summary statistics for each column of the dataframe
+// ElemSafe returns the element on row `r` and column `c`, or an error if any of
+// the indexes is out of bounds.
+func (df GotaDataFrame) ElemSafe(r, c int) (series.Element, error) {
+	if df.Err != nil {
+		return nil, df.Err
+	}
+	if c < 0 || c >= df.ncols {
+		return nil, fmt.Errorf("elem: column index %d out of range [0, %d)", c, df.ncols)
+	}
+	if r < 0 || r >= df.nrows {
+		return nil, fmt.Errorf("elem: row index %d out of range [0, %d)", r, df.nrows)
+	}
+	return df.columns[c].Elem(r), nil
+}
+
+// At returns the element on the given row of the column with the given name.
+func (df GotaDataFrame) At(row int, colname string) (series.Element, error) {
+	if df.Err != nil {
+		return nil, df.Err
+	}
+	idx := df.ColIndex(colname)
+	if idx < 0 {
+		return nil, fmt.Errorf("at: unknown column name %s", colname)
+	}
+	if row < 0 || row >= df.nrows {
+		return nil, fmt.Errorf("at: row index %d out of range [0, %d)", row, df.nrows)
+	}
+	return df.columns[idx].Elem(row), nil
+}
+
+// SetAt returns a copy of the DataFrame where the element on the given row of
+// the column with the given name is replaced by value, converted to the type
+// of the column. It fails if the value can't be converted, unless it is a NaN
+// value: nil, a floating point NaN, "NaN" or a NaN Element.
+func (df GotaDataFrame) SetAt(row int, colname string, value interface{}) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	idx := df.ColIndex(colname)
+	if idx < 0 {
+		return GotaDataFrame{Err: fmt.Errorf("set at: unknown column name %s", colname)}
+	}
+	if row < 0 || row >= df.nrows {
+		return GotaDataFrame{Err: fmt.Errorf("set at: row index %d out of range [0, %d)", row, df.nrows)}
+	}
+	col := df.columns[idx]
+	v := series.New([]interface{}{value}, col.Type(), col.Name)
+	if v.Err != nil {
+		return GotaDataFrame{Err: fmt.Errorf("set at: %v", v.Err)}
+	}
+	if v.Elem(0).IsNA() && !isNAValue(value) {
+		return GotaDataFrame{Err: fmt.Errorf("set at: can't convert %v to %v", value, col.Type())}
+	}
+	s := col.Copy().Set(row, v)
+	if s.Err != nil {
+		return GotaDataFrame{Err: fmt.Errorf("set at: %v", s.Err)}
+	}
+
+	columns := make([]series.Series1, df.ncols)
+	copy(columns, df.columns)
+	columns[idx] = s
+	return New(columns...)
+}
+
+// isNAValue reports whether the given value stands for a NaN element, so that
+// converting it to a NaN element is not a conversion error.
+func isNAValue(value interface{}) bool {
+	switch val := value.(type) {
+	case nil:
+		return true
+	case float64:
+		return math.IsNaN(val)
+	case float32:
+		return math.IsNaN(float64(val))
+	case string:
+		return val == "NaN"
+	case series.Element:
+		return val.IsNA()
+	}
+	return false
+}
+
+// Describe prints the summary statistics for each column of the dataframe.
+// String and Time columns are only summarized by their min and max. Bool
+// columns are summarized by the proportion of true elements, shown as their
+// mean, and by the count of true and false elements, shown on two extra rows
+// that are only present if the DataFrame has Bool columns.
 func (df GotaDataFrame) Describe() DataFrame {
-	labels := series.Strings([]string{
-		"mean",
-		"median",
-		"stddev",
-		"min",
-		"25%",
-		"50%",
-		"75%",
-		"max",
-	})
+	return df.describe([]float64{0.25, 0.50, 0.75})
+}
+
+// DescribeQuantiles is like Describe, but reporting the given quantiles instead
+// of the 25%, 50% and 75% percentiles. Every quantile must be within [0, 1].
+func (df GotaDataFrame) DescribeQuantiles(qs ...float64) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	for _, q := range qs {
+		if q < 0 || q > 1 || math.IsNaN(q) {
+			return GotaDataFrame{Err: fmt.Errorf("describe quantiles: quantile %v out of range [0, 1]", q)}
+		}
+	}
+	return df.describe(qs)
+}
+
+// describe returns the summary statistics for each column of the dataframe,
+// with a row for each of the given quantiles.
+func (df GotaDataFrame) describe(qs []float64) DataFrame {
+	hasBools := false
+	for _, col := range df.columns {
+		if col.Type() == series.Bool {
+			hasBools = true
+		}
+	}
+
+	rows := []string{"mean", "median", "stddev", "min"}
+	for _, q := range qs {
+		rows = append(rows, strconv.FormatFloat(q*100, 'g', 10, 64)+"%")
+	}
+	rows = append(rows, "max")
+	if hasBools {
+		rows = append(rows, "true", "false")
+	}
+	labels := series.Strings(rows)
 	labels.Name = "column"
 
 	ss := []series.Series1{labels}
@@ -1328,37 +2626,81 @@ func (df GotaDataFrame) Describe() DataFrame {
 		var newCol series.Series1
 		switch col.Type() {
 		case series.String:
-			newCol = series.New([]string{
-				"-",
-				"-",
-				"-",
-				col.MinStr(),
-				"-",
-				"-",
-				"-",
-				col.MaxStr(),
-			},
-				col.Type(),
-				col.Name,
-			)
+			values := []string{"-", "-", "-", col.MinStr()}
+			for range qs {
+				values = append(values, "-")
+			}
+			values = append(values, col.MaxStr())
+			if hasBools {
+				values = append(values, "-", "-")
+			}
+			newCol = series.New(values, col.Type(), col.Name)
 		case series.Bool:
-			fallthrough
+			trues, falses := 0, 0
+			for i := 0; i < col.Len(); i++ {
+				e := col.Elem(i)
+				if e.IsNA() {
+					continue
+				}
+				if b, err := e.Bool(); err == nil && b {
+					trues++
+				} else {
+					falses++
+				}
+			}
+			proportion := "NaN"
+			if trues+falses > 0 {
+				proportion = strconv.FormatFloat(float64(trues)/float64(trues+falses), 'f', 6, 64)
+			}
+			values := []string{proportion}
+			for k := 1; k < len(rows)-2; k++ {
+				values = append(values, "-")
+			}
+			values = append(values, strconv.Itoa(trues), strconv.Itoa(falses))
+			newCol = series.New(values, series.String, col.Name)
+		case series.Time:
+			var min, max series.Element
+			for i := 0; i < col.Len(); i++ {
+				e := col.Elem(i)
+				if e.IsNA() {
+					continue
+				}
+				if min == nil || e.Less(min) {
+					min = e
+				}
+				if max == nil || e.Greater(max) {
+					max = e
+				}
+			}
+			minStr, maxStr := "NaN", "NaN"
+			if min != nil {
+				minStr, maxStr = min.String(), max.String()
+			}
+			values := []string{"-", "-", "-", minStr}
+			for range qs {
+				values = append(values, "-")
+			}
+			values = append(values, maxStr)
+			if hasBools {
+				values = append(values, "-", "-")
+			}
+			newCol = series.New(values, series.String, col.Name)
 		case series.Float:
 			fallthrough
+		case series.Uint:
+			fallthrough
 		case series.Int:
-			newCol = series.New([]float64{
-				col.Mean(),
-				col.Median(),
-				col.StdDev(),
-				col.Min(),
-				col.Quantile(0.25),
-				col.Quantile(0.50),
-				col.Quantile(0.75),
-				col.Max(),
-			},
-				series.Float,
-				col.Name,
-			)
+			values := []float64{col.Mean(), col.Median(), col.StdDev(), col.Min()}
+			for _, q := range qs {
+				values = append(values, col.Quantile(q))
+			}
+			values = append(values, col.Max())
+			if hasBools {
+				values = append(values, math.NaN(), math.NaN())
+			}
+			newCol = series.New(values, series.Float, col.Name)
+		default:
+			return GotaDataFrame{Err: fmt.Errorf("describe: column %q has unsupported type %v", col.Name, col.Type())}
 		}
 		ss = append(ss, newCol)
 	}
@@ -1367,6 +2709,49 @@ func (df GotaDataFrame) Describe() DataFrame {
 	return ddf
 }
 
+// NullCount returns the number of NaN elements of each column, by column name.
+func (df GotaDataFrame) NullCount() map[string]int {
+	if df.Err != nil {
+		return nil
+	}
+	counts := make(map[string]int, df.ncols)
+	for _, col := range df.columns {
+		n := 0
+		for _, isNaN := range col.IsNaN() {
+			if isNaN {
+				n++
+			}
+		}
+		counts[col.Name] = n
+	}
+	return counts
+}
+
+// Info returns a summary of the columns of the DataFrame, with one row per
+// column holding its name, its type and its number of non NaN and NaN
+// elements.
+func (df GotaDataFrame) Info() DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	nulls := df.NullCount()
+	names := df.Names()
+	types := make([]string, df.ncols)
+	nonNullCounts := make([]int, df.ncols)
+	nullCounts := make([]int, df.ncols)
+	for i, col := range df.columns {
+		types[i] = string(col.Type())
+		nullCounts[i] = nulls[col.Name]
+		nonNullCounts[i] = df.nrows - nullCounts[i]
+	}
+	return New(
+		series.New(names, series.String, "column"),
+		series.New(types, series.String, "type"),
+		series.New(nonNullCounts, series.Int, "non_null"),
+		series.New(nullCounts, series.Int, "null"),
+	)
+}
+
 func (df GotaDataFrame) Columns() []series.Series1 {
 	return df.columns
 }
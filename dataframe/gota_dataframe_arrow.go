@@ -0,0 +1,135 @@
+package dataframe
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/v17/arrow"
+	"github.com/apache/arrow/go/v17/arrow/array"
+	"github.com/apache/arrow/go/v17/arrow/memory"
+	"github.com/go-gota/gota/series"
+)
+
+// ToArrow converts the DataFrame into an arrow.Record allocated with the given
+// memory.Allocator. String columns are mapped to Arrow strings, Int to int64,
+// Float to float64 and Bool to booleans, and NaN elements are marked as null
+// on the validity bitmap. The caller is responsible for releasing the Record.
+func (df GotaDataFrame) ToArrow(pool memory.Allocator) (arrow.Record, error) {
+	if df.Err != nil {
+		return nil, df.Err
+	}
+
+	fields := make([]arrow.Field, df.ncols)
+	for i, col := range df.columns {
+		var t arrow.DataType
+		switch col.Type() {
+		case series.String:
+			t = arrow.BinaryTypes.String
+		case series.Int:
+			t = arrow.PrimitiveTypes.Int64
+		case series.Float:
+			t = arrow.PrimitiveTypes.Float64
+		case series.Bool:
+			t = arrow.FixedWidthTypes.Boolean
+		default:
+			return nil, fmt.Errorf("to arrow: type %s of column %s is not supported", col.Type(), col.Name)
+		}
+		fields[i] = arrow.Field{Name: col.Name, Type: t, Nullable: true}
+	}
+
+	b := array.NewRecordBuilder(pool, arrow.NewSchema(fields, nil))
+	defer b.Release()
+	for j, col := range df.columns {
+		isNaN := col.IsNaN()
+		for i := 0; i < df.nrows; i++ {
+			if isNaN[i] {
+				b.Field(j).AppendNull()
+				continue
+			}
+			e := col.Elem(i)
+			switch fb := b.Field(j).(type) {
+			case *array.StringBuilder:
+				fb.Append(e.String())
+			case *array.Int64Builder:
+				v, err := e.Int()
+				if err != nil {
+					return nil, fmt.Errorf("to arrow: %v", err)
+				}
+				fb.Append(int64(v))
+			case *array.Float64Builder:
+				fb.Append(e.Float())
+			case *array.BooleanBuilder:
+				v, err := e.Bool()
+				if err != nil {
+					return nil, fmt.Errorf("to arrow: %v", err)
+				}
+				fb.Append(v)
+			}
+		}
+	}
+	return b.NewRecord(), nil
+}
+
+// FromArrow creates a new DataFrame from an arrow.Record. Arrow strings are
+// loaded as String columns, integers as Int, floating point numbers as Float
+// and booleans as Bool. Null values are loaded as NaN.
+func FromArrow(rec arrow.Record) GotaDataFrame {
+	if rec == nil {
+		return GotaDataFrame{Err: fmt.Errorf("from arrow: record is nil")}
+	}
+
+	nrows := int(rec.NumRows())
+	columns := make([]series.Series1, rec.NumCols())
+	for j := range columns {
+		arr := rec.Column(j)
+		name := rec.ColumnName(j)
+		t, err := arrowSeriesType(arr.DataType())
+		if err != nil {
+			return GotaDataFrame{Err: fmt.Errorf("from arrow: column %s: %v", name, err)}
+		}
+		values := make([]interface{}, nrows)
+		for i := 0; i < nrows; i++ {
+			if arr.IsNull(i) {
+				continue
+			}
+			switch a := arr.(type) {
+			case *array.String:
+				values[i] = a.Value(i)
+			case *array.LargeString:
+				values[i] = a.Value(i)
+			case *array.Int8:
+				values[i] = int(a.Value(i))
+			case *array.Int16:
+				values[i] = int(a.Value(i))
+			case *array.Int32:
+				values[i] = int(a.Value(i))
+			case *array.Int64:
+				values[i] = int(a.Value(i))
+			case *array.Float32:
+				values[i] = float64(a.Value(i))
+			case *array.Float64:
+				values[i] = a.Value(i)
+			case *array.Boolean:
+				values[i] = a.Value(i)
+			}
+		}
+		columns[j] = series.New(values, t, name)
+	}
+	return New(columns...)
+}
+
+// arrowSeriesType returns the Series type used to load an Arrow column of the
+// given type.
+func arrowSeriesType(t arrow.DataType) (series.Type, error) {
+	switch t.ID() {
+	case arrow.STRING, arrow.LARGE_STRING:
+		return series.String, nil
+	case arrow.INT8, arrow.INT16, arrow.INT32, arrow.INT64:
+		return series.Int, nil
+	case arrow.FLOAT32, arrow.FLOAT64:
+		return series.Float, nil
+	case arrow.BOOL:
+		return series.Bool, nil
+	default:
+		return "", fmt.Errorf("type %s is not supported", t)
+	}
+}
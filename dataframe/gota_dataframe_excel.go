@@ -0,0 +1,148 @@
+package dataframe
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/go-gota/gota/series"
+	"github.com/xuri/excelize/v2"
+)
+
+// ReadExcel reads the given sheet of a xlsx file from a io.Reader and builds a
+// DataFrame with its cells. If sheet is the empty string, the first sheet of
+// the file is read. Leading empty rows are skipped, so the first non-empty row
+// is used as header unless HasHeader is disabled. Empty cells are loaded as
+// NaN and the column types are detected as in LoadRecords.
+func ReadExcel(r io.Reader, sheet string, options ...LoadOption) GotaDataFrame {
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return GotaDataFrame{Err: fmt.Errorf("read excel: %v", err)}
+	}
+	defer f.Close()
+
+	if sheet == "" {
+		sheet = f.GetSheetName(0)
+	}
+	rows, err := f.GetRows(sheet)
+	if err != nil {
+		return GotaDataFrame{Err: fmt.Errorf("read excel: %v", err)}
+	}
+
+	// Skip leading empty rows
+	for len(rows) > 0 && len(rows[0]) == 0 {
+		rows = rows[1:]
+	}
+
+	// Trailing empty cells are not returned, so rows are padded to the same
+	// width.
+	ncols := 0
+	for _, row := range rows {
+		if len(row) > ncols {
+			ncols = len(row)
+		}
+	}
+	records := make([][]string, len(rows))
+	for i, row := range rows {
+		record := make([]string, ncols)
+		for j, cell := range row {
+			switch cell {
+			case "TRUE":
+				cell = "true"
+			case "FALSE":
+				cell = "false"
+			}
+			record[j] = cell
+		}
+		records[i] = record
+	}
+
+	options = append([]LoadOption{NaNValues([]string{"", "NA", "NaN", "<nil>"})}, options...)
+	return LoadRecords(records, options...)
+}
+
+// WriteExcel writes the DataFrame to the given io.Writer as a xlsx file with a
+// single sheet with the given name. Cells keep the type of their column and
+// NaN elements are left empty.
+func (df GotaDataFrame) WriteExcel(w io.Writer, sheet string, options ...WriteOption) error {
+	if df.Err != nil {
+		return df.Err
+	}
+
+	// Set the default write options
+	cfg := writeOptions{
+		writeHeader: true,
+	}
+
+	// Set any custom write options
+	for _, option := range options {
+		option(&cfg)
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+	if sheet == "" {
+		sheet = f.GetSheetName(0)
+	}
+	if sheet != f.GetSheetName(0) {
+		if err := f.SetSheetName(f.GetSheetName(0), sheet); err != nil {
+			return fmt.Errorf("write excel: %v", err)
+		}
+	}
+
+	row := 1
+	if cfg.writeHeader {
+		for j, colname := range df.Names() {
+			if err := setExcelCell(f, sheet, j+1, row, colname); err != nil {
+				return err
+			}
+		}
+		row++
+	}
+	for i := 0; i < df.nrows; i++ {
+		for j, col := range df.columns {
+			e := col.Elem(i)
+			if e.IsNA() {
+				continue
+			}
+			var value interface{}
+			switch col.Type() {
+			case series.Int:
+				v, err := e.Int()
+				if err != nil {
+					return fmt.Errorf("write excel: %v", err)
+				}
+				value = v
+			case series.Float:
+				value = e.Float()
+			case series.Bool:
+				v, err := e.Bool()
+				if err != nil {
+					return fmt.Errorf("write excel: %v", err)
+				}
+				value = v
+			default:
+				value = e.String()
+			}
+			if err := setExcelCell(f, sheet, j+1, row+i, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := f.Write(w); err != nil {
+		return fmt.Errorf("write excel: %v", err)
+	}
+	return nil
+}
+
+// setExcelCell sets the value of the cell on the given 1-based column and row.
+func setExcelCell(f *excelize.File, sheet string, col, row int, value interface{}) error {
+	cell, err := excelize.CoordinatesToCellName(col, row)
+	if err != nil {
+		return fmt.Errorf("write excel: %v", err)
+	}
+	if err := f.SetCellValue(sheet, cell, value); err != nil {
+		return fmt.Errorf("write excel: %v", err)
+	}
+	return nil
+}
@@ -1,6 +1,9 @@
 package dataframe
 
 import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
@@ -9,10 +12,12 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-gota/gota/series"
 	"golang.org/x/net/html"
 	"golang.org/x/net/html/atom"
+	"gonum.org/v1/gonum/mat"
 )
 
 // Read/Write Methods
@@ -50,6 +55,38 @@ type loadOptions struct {
 
 	// The types of specific columns can be specified via column name.
 	types map[string]series.Type
+
+	// The layout used to detect and parse Time columns.
+	timeLayout string
+
+	// If set, nested objects and arrays are flattened into columns whose
+	// names join the keys with flattenSep.
+	flatten    bool
+	flattenSep string
+
+	// If set, the columns loaded from JSON keep the order in which their keys
+	// first appear instead of being sorted by name.
+	preserveOrder bool
+
+	// The order of the keys of the JSON objects, as read by ReadJSON.
+	keyOrder []string
+
+	// If set, ReadFWF reports lines shorter than the total width as an error
+	// instead of padding them.
+	strictWidths bool
+
+	// The maximum number of data rows read by ReadCSV, if positive.
+	maxRows int
+
+	// The number of rows to ignore at the beginning and at the end of the
+	// input, before detecting the header.
+	skipRows   int
+	skipFooter int
+
+	// The separators used to format numbers, which are normalized before
+	// detecting and parsing the types of the columns.
+	thousandsSep rune
+	decimalSep   rune
 }
 
 // DefaultType sets the defaultType option for loadOptions.
@@ -94,6 +131,101 @@ func WithTypes(coltypes map[string]series.Type) LoadOption {
 	}
 }
 
+// TimeLayout sets the layout, as understood by time.Parse, used to detect and
+// parse Time columns. By default RFC3339 timestamps are detected.
+func TimeLayout(layout string) LoadOption {
+	return func(c *loadOptions) {
+		c.timeLayout = layout
+	}
+}
+
+// FlattenJSON sets whether the nested objects of the maps loaded by LoadMaps
+// and ReadJSON are flattened into one column per leaf value, named by joining
+// the keys with sep, such as "address.city". The elements of arrays are
+// flattened the same way using their index as key, such as "tags.0".
+func FlattenJSON(sep string) LoadOption {
+	return func(c *loadOptions) {
+		c.flatten = true
+		c.flattenSep = sep
+	}
+}
+
+// PreserveOrder sets whether the columns read by ReadJSON and ReadJSONLines
+// follow the order in which their keys first appear on the JSON objects. By
+// default, as well as for the maps given to LoadMaps, whose keys have no order,
+// the columns are sorted by name.
+func PreserveOrder(b bool) LoadOption {
+	return func(c *loadOptions) {
+		c.preserveOrder = b
+	}
+}
+
+// keyOrder sets the order of the keys of the loaded JSON objects.
+func keyOrder(keys []string) LoadOption {
+	return func(c *loadOptions) {
+		c.keyOrder = keys
+	}
+}
+
+// MaxRows sets the maximum number of data rows, not counting the header, read
+// by ReadCSV, which stops reading the input once they are reached. Types are
+// detected on the rows read. Values lower or equal than zero read every row.
+func MaxRows(n int) LoadOption {
+	return func(c *loadOptions) {
+		c.maxRows = n
+	}
+}
+
+// SkipRows sets the number of rows ignored at the beginning of the input, such
+// as the title banners of spreadsheet exports. The header, if any, is the first
+// row after them. ReadCSV skips whole lines, so the skipped rows don't need to
+// have the same number of fields as the rest.
+func SkipRows(n int) LoadOption {
+	return func(c *loadOptions) {
+		c.skipRows = n
+	}
+}
+
+// SkipFooter sets the number of rows ignored at the end of the input, such as
+// summary lines. They don't need to have the same number of fields as the
+// rest. If ReadCSV stops reading before the end of the input because of
+// MaxRows, no rows are skipped.
+func SkipFooter(n int) LoadOption {
+	return func(c *loadOptions) {
+		c.skipFooter = n
+	}
+}
+
+// ThousandsSep sets the rune used to group the digits of numbers, such as ','
+// in "1,234.56" or '.' in "1.234,56". It is removed from the values of numeric
+// columns where every value is a number once the separators are normalized, so
+// they can be detected and loaded as Int or Float. Columns given another type
+// with WithTypes keep their values verbatim.
+func ThousandsSep(r rune) LoadOption {
+	return func(c *loadOptions) {
+		c.thousandsSep = r
+	}
+}
+
+// DecimalSep sets the rune used as decimal separator, such as ',' in
+// "1.234,56". It is replaced by '.' in the values of numeric columns where
+// every value is a number once the separators are normalized, so they can be
+// detected and loaded as Float. Columns given another type with WithTypes keep
+// their values verbatim.
+func DecimalSep(r rune) LoadOption {
+	return func(c *loadOptions) {
+		c.decimalSep = r
+	}
+}
+
+// StrictWidths sets whether ReadFWF reports an error for lines shorter than
+// the sum of the column widths. By default they are padded with empty fields.
+func StrictWidths(b bool) LoadOption {
+	return func(c *loadOptions) {
+		c.strictWidths = b
+	}
+}
+
 // WithDelimiter sets the csv delimiter other than ',', for example '\t'
 func WithDelimiter(b rune) LoadOption {
 	return func(c *loadOptions) {
@@ -145,6 +277,19 @@ func WithComments(b rune) LoadOption {
 //	// Field will be parsed with column name `Field` and type string.
 //	Field int `dataframe:",string"`
 //
+//	// The fields of Embedded will be parsed as if they were fields of the
+//	// outer struct.
+//	Embedded
+//
+//	// The fields of Field will be parsed with column names prefixed by
+//	// `field.`, as in `field.Inner`.
+//	Field Nested `dataframe:"field"`
+//
+// Pointer fields are dereferenced, with nil pointers resulting in NaN, and
+// time.Time fields are parsed as Time columns. If a time.Time field is parsed
+// with another type, such as `dataframe:",string"`, it is formatted with the
+// layout set by TimeLayout.
+//
 // If the struct tags and the given LoadOptions contradict each other, the later
 // will have preference over the former.
 func LoadStructs(i interface{}, options ...LoadOption) GotaDataFrame {
@@ -158,6 +303,7 @@ func LoadStructs(i interface{}, options ...LoadOption) GotaDataFrame {
 		detectTypes: true,
 		hasHeader:   true,
 		nanValues:   []string{"NA", "NaN", "<nil>"},
+		timeLayout:  time.RFC3339,
 	}
 
 	// Set any custom load options
@@ -176,36 +322,14 @@ func LoadStructs(i interface{}, options ...LoadOption) GotaDataFrame {
 			return GotaDataFrame{Err: fmt.Errorf("load: can't create DataFrame from empty slice")}
 		}
 
-		numFields := val.Index(0).Type().NumField()
+		fields, err := structFields(tpy.Elem(), nil, "")
+		if err != nil {
+			return GotaDataFrame{Err: err}
+		}
 		var columns []series.Series1
-		for j := 0; j < numFields; j++ {
-			// Extract field metadata
-			if !val.Index(0).Field(j).CanInterface() {
-				continue
-			}
-			field := val.Index(0).Type().Field(j)
-			fieldName := field.Name
-			fieldType := field.Type.String()
-
-			// Process struct tags
-			fieldTags := field.Tag.Get("dataframe")
-			if fieldTags == "-" {
-				continue
-			}
-			tagOpts := strings.Split(fieldTags, ",")
-			if len(tagOpts) > 2 {
-				return GotaDataFrame{Err: fmt.Errorf("malformed struct tag on field %s: %s", fieldName, fieldTags)}
-			}
-			if len(tagOpts) > 0 {
-				if name := strings.TrimSpace(tagOpts[0]); name != "" {
-					fieldName = name
-				}
-				if len(tagOpts) == 2 {
-					if tagType := strings.TrimSpace(tagOpts[1]); tagType != "" {
-						fieldType = tagType
-					}
-				}
-			}
+		for _, field := range fields {
+			fieldName := field.name
+			fieldType := field.typ
 
 			// Handle `types` option
 			var t series.Type
@@ -228,8 +352,18 @@ func LoadStructs(i interface{}, options ...LoadOption) GotaDataFrame {
 			// Create Series for this field
 			elements := make([]interface{}, val.Len())
 			for i := 0; i < val.Len(); i++ {
-				fieldValue := val.Index(i).Field(j)
+				fieldValue := val.Index(i).FieldByIndex(field.index)
+				if fieldValue.Kind() == reflect.Ptr {
+					if fieldValue.IsNil() {
+						elements[i] = nil
+						continue
+					}
+					fieldValue = fieldValue.Elem()
+				}
 				elements[i] = fieldValue.Interface()
+				if tm, ok := elements[i].(time.Time); ok && t != series.Time {
+					elements[i] = tm.Format(cfg.timeLayout)
+				}
 
 				// Handle `nanValues` option
 				if findInStringSlice(fmt.Sprint(elements[i]), cfg.nanValues) != -1 {
@@ -252,6 +386,83 @@ func LoadStructs(i interface{}, options ...LoadOption) GotaDataFrame {
 		"load: type %s (%s) is not supported, must be []struct", tpy.Name(), tpy.Kind())}
 }
 
+var timeType = reflect.TypeOf(time.Time{})
+
+// structField describes a field of a struct loaded as a column by LoadStructs.
+type structField struct {
+	index []int
+	name  string
+	typ   string
+}
+
+// structFields returns the fields of the struct type t that are loaded as
+// columns, following the `dataframe` struct tags. The fields of embedded
+// structs are promoted, and the fields of nested structs are flattened with
+// their names prefixed by the name of the nested struct and a dot. Structs
+// with a type on their tag and time.Time are loaded as a single column.
+func structFields(t reflect.Type, index []int, prefix string) ([]structField, error) {
+	var fields []structField
+	for j := 0; j < t.NumField(); j++ {
+		field := t.Field(j)
+		isStruct := field.Type.Kind() == reflect.Struct && field.Type != timeType
+		// Exported fields of unexported embedded structs are still accessible
+		if !field.IsExported() && !(field.Anonymous && isStruct) {
+			continue
+		}
+		fieldName := field.Name
+		fieldType := field.Type.String()
+		if field.Type.Kind() == reflect.Ptr {
+			// Pointers are dereferenced when loading the values
+			fieldType = field.Type.Elem().String()
+		}
+
+		// Process struct tags
+		fieldTags := field.Tag.Get("dataframe")
+		if fieldTags == "-" {
+			continue
+		}
+		tagOpts := strings.Split(fieldTags, ",")
+		if len(tagOpts) > 2 {
+			return nil, fmt.Errorf("malformed struct tag on field %s: %s", prefix+fieldName, fieldTags)
+		}
+		tagName := strings.TrimSpace(tagOpts[0])
+		if tagName != "" {
+			fieldName = tagName
+		}
+		if len(tagOpts) == 2 {
+			if tagType := strings.TrimSpace(tagOpts[1]); tagType != "" {
+				fieldType = tagType
+				isStruct = false
+			}
+		}
+
+		fieldIndex := make([]int, len(index)+1)
+		copy(fieldIndex, index)
+		fieldIndex[len(index)] = j
+		if isStruct {
+			fieldPrefix := prefix + fieldName + "."
+			if field.Anonymous && tagName == "" {
+				fieldPrefix = prefix
+			}
+			nested, err := structFields(field.Type, fieldIndex, fieldPrefix)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, nested...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		fields = append(fields, structField{
+			index: fieldIndex,
+			name:  prefix + fieldName,
+			typ:   fieldType,
+		})
+	}
+	return fields, nil
+}
+
 func parseType(s string) (series.Type, error) {
 	switch s {
 	case "float", "float64", "float32":
@@ -262,10 +473,38 @@ func parseType(s string) (series.Type, error) {
 		return series.String, nil
 	case "bool":
 		return series.Bool, nil
+	case "time.Time", "time":
+		return series.Time, nil
 	}
 	return "", fmt.Errorf("type (%s) is not supported", s)
 }
 
+// normalizeNumbers returns the values with the thousands separator removed and
+// the decimal separator replaced by '.', as long as all of them are numbers
+// once normalized. Otherwise, the values are returned unchanged.
+func normalizeNumbers(values []string, thousandsSep, decimalSep rune) []string {
+	normalized := make([]string, len(values))
+	for i, str := range values {
+		if str == "" || str == "NaN" {
+			normalized[i] = str
+			continue
+		}
+		normalized[i] = strings.Map(func(r rune) rune {
+			switch r {
+			case thousandsSep:
+				return -1
+			case decimalSep:
+				return '.'
+			}
+			return r
+		}, str)
+		if _, err := strconv.ParseFloat(normalized[i], 64); err != nil {
+			return values
+		}
+	}
+	return normalized
+}
+
 // LoadRecords creates a new DataFrame based on the given records.
 func LoadRecords(records [][]string, options ...LoadOption) GotaDataFrame {
 	// Set the default load options
@@ -274,6 +513,7 @@ func LoadRecords(records [][]string, options ...LoadOption) GotaDataFrame {
 		detectTypes: true,
 		hasHeader:   true,
 		nanValues:   []string{"NA", "NaN", "<nil>"},
+		timeLayout:  time.RFC3339,
 	}
 
 	// Set any custom load options
@@ -281,6 +521,13 @@ func LoadRecords(records [][]string, options ...LoadOption) GotaDataFrame {
 		option(&cfg)
 	}
 
+	if cfg.skipRows > 0 || cfg.skipFooter > 0 {
+		start, end := max(cfg.skipRows, 0), len(records)-max(cfg.skipFooter, 0)
+		if start >= end {
+			return GotaDataFrame{Err: fmt.Errorf("load records: empty DataFrame")}
+		}
+		records = records[start:end]
+	}
 	if len(records) == 0 {
 		return GotaDataFrame{Err: fmt.Errorf("load records: empty DataFrame")}
 	}
@@ -314,18 +561,37 @@ func LoadRecords(records [][]string, options ...LoadOption) GotaDataFrame {
 				rawcol[j] = "NaN"
 			}
 		}
-		rawcols[i] = rawcol
+		hasSeps := cfg.thousandsSep != 0 || cfg.decimalSep != 0
 
 		t, ok := cfg.types[colname]
 		if !ok {
 			t = cfg.defaultType
 			if cfg.detectTypes {
-				if l, err := findType(rawcol); err == nil {
+				detectcol := rawcol
+				if hasSeps {
+					detectcol = normalizeNumbers(rawcol, cfg.thousandsSep, cfg.decimalSep)
+				}
+				if l, err := findType(detectcol, cfg.timeLayout); err == nil {
 					t = l
 				}
 			}
 		}
 		types[i] = t
+
+		// Only numeric columns are normalized, so columns typed as String keep
+		// their values verbatim
+		if hasSeps && (t == series.Int || t == series.Uint || t == series.Float) {
+			rawcol = normalizeNumbers(rawcol, cfg.thousandsSep, cfg.decimalSep)
+		}
+		rawcols[i] = rawcol
+
+		if t == series.Time {
+			for j, str := range rawcol {
+				if parsed, err := time.Parse(cfg.timeLayout, str); err == nil {
+					rawcol[j] = parsed.Format(series.TimeFormat)
+				}
+			}
+		}
 	}
 
 	columns := make([]series.Series1, len(headers))
@@ -360,6 +626,18 @@ func LoadMaps(maps []map[string]interface{}, options ...LoadOption) DataFrame {
 	if len(maps) == 0 {
 		return GotaDataFrame{Err: fmt.Errorf("load maps: empty array")}
 	}
+	cfg := loadOptions{}
+	for _, option := range options {
+		option(&cfg)
+	}
+	if cfg.flatten {
+		flat := make([]map[string]interface{}, len(maps))
+		for i, m := range maps {
+			flat[i] = make(map[string]interface{}, len(m))
+			flattenMap("", cfg.flattenSep, m, flat[i])
+		}
+		maps = flat
+	}
 	inStrSlice := func(i string, s []string) bool {
 		for _, v := range s {
 			if v == i {
@@ -378,6 +656,20 @@ func LoadMaps(maps []map[string]interface{}, options ...LoadOption) DataFrame {
 		}
 	}
 	sort.Strings(colnames)
+	if cfg.preserveOrder && cfg.keyOrder != nil {
+		ordered := make([]string, 0, len(colnames))
+		for _, k := range cfg.keyOrder {
+			if inStrSlice(k, colnames) && !inStrSlice(k, ordered) {
+				ordered = append(ordered, k)
+			}
+		}
+		for _, k := range colnames {
+			if !inStrSlice(k, ordered) {
+				ordered = append(ordered, k)
+			}
+		}
+		colnames = ordered
+	}
 	records := make([][]string, len(maps)+1)
 	records[0] = colnames
 	for k, m := range maps {
@@ -395,6 +687,30 @@ func LoadMaps(maps []map[string]interface{}, options ...LoadOption) DataFrame {
 	return LoadRecords(records, options...)
 }
 
+// flattenMap stores on out the leaf values of the given value, which can be a
+// nested structure of maps and slices, with their keys prefixed by the given
+// prefix and joined by sep.
+func flattenMap(prefix, sep string, v interface{}, out map[string]interface{}) {
+	join := func(k string) string {
+		if prefix == "" {
+			return k
+		}
+		return prefix + sep + k
+	}
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, e := range val {
+			flattenMap(join(k), sep, e, out)
+		}
+	case []interface{}:
+		for i, e := range val {
+			flattenMap(join(strconv.Itoa(i)), sep, e, out)
+		}
+	default:
+		out[prefix] = val
+	}
+}
+
 // LoadMatrix loads the given Matrix as a DataFrame
 // TODO: Add Loadoptions
 func LoadMatrix(mat Matrix) GotaDataFrame {
@@ -424,33 +740,337 @@ func LoadMatrix(mat Matrix) GotaDataFrame {
 	return df
 }
 
+// ToMatrix returns a read-only Matrix with the values of the DataFrame, which
+// must only have Int, Uint and Float columns. NaN elements are returned as NaN. The
+// returned Matrix also implements gonum's mat.Matrix, so it can be used
+// directly in gonum's linear algebra routines.
+func (df GotaDataFrame) ToMatrix() (Matrix, error) {
+	if df.Err != nil {
+		return nil, df.Err
+	}
+	values := make([][]float64, df.ncols)
+	for j, col := range df.columns {
+		if t := col.Type(); t != series.Int && t != series.Uint && t != series.Float {
+			return nil, fmt.Errorf("to matrix: column %q is not numeric (%s)", col.Name, t)
+		}
+		values[j] = col.Float()
+	}
+	return dataFrameMatrix{nrows: df.nrows, columns: values}, nil
+}
+
+// dataFrameMatrix is the Matrix returned by ToMatrix, holding the values of
+// every column of the DataFrame.
+type dataFrameMatrix struct {
+	nrows   int
+	columns [][]float64
+}
+
+func (m dataFrameMatrix) Dims() (r, c int) {
+	return m.nrows, len(m.columns)
+}
+
+func (m dataFrameMatrix) At(i, j int) float64 {
+	return m.columns[j][i]
+}
+
+func (m dataFrameMatrix) T() mat.Matrix {
+	return mat.Transpose{Matrix: m}
+}
+
 // ReadCSV reads a CSV file from a io.Reader and builds a DataFrame with the
 // resulting records.
 func ReadCSV(r io.Reader, options ...LoadOption) GotaDataFrame {
-	csvReader := csv.NewReader(r)
 	cfg := loadOptions{
 		delimiter:  ',',
 		lazyQuotes: false,
 		comment:    0,
+		hasHeader:  true,
 	}
 	for _, option := range options {
 		option(&cfg)
 	}
 
+	br := bufio.NewReader(r)
+	for i := 0; i < cfg.skipRows; i++ {
+		if _, err := br.ReadString('\n'); err == io.EOF {
+			break
+		} else if err != nil {
+			return GotaDataFrame{Err: err}
+		}
+	}
+	csvReader := csv.NewReader(br)
 	csvReader.Comma = cfg.delimiter
 	csvReader.LazyQuotes = cfg.lazyQuotes
 	csvReader.Comment = cfg.comment
+	if cfg.skipFooter > 0 {
+		// The footer can have a different number of fields, so they are
+		// checked after removing it
+		csvReader.FieldsPerRecord = -1
+	}
 
-	records, err := csvReader.ReadAll()
+	limit := -1
+	if cfg.maxRows > 0 {
+		limit = cfg.maxRows
+		if cfg.hasHeader {
+			limit++
+		}
+	}
+	var records [][]string
+	eof := false
+	for limit < 0 || len(records) < limit {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			eof = true
+			break
+		}
+		if err != nil {
+			return GotaDataFrame{Err: err}
+		}
+		records = append(records, record)
+	}
+	if cfg.skipFooter > 0 {
+		if eof {
+			records = records[:max(len(records)-cfg.skipFooter, 0)]
+		}
+		for i, record := range records {
+			if len(record) != len(records[0]) {
+				return GotaDataFrame{Err: fmt.Errorf("read csv: record %d has %d fields, expected %d", i+1, len(record), len(records[0]))}
+			}
+		}
+	}
+	// The rows have already been skipped
+	csvOptions := make([]LoadOption, 0, len(options)+2)
+	csvOptions = append(csvOptions, options...)
+	csvOptions = append(csvOptions, SkipRows(0), SkipFooter(0))
+	return LoadRecords(records, csvOptions...)
+}
+
+// ReadCSVGzip reads a gzip compressed CSV file from a io.Reader and builds a
+// DataFrame with the resulting records, as in ReadCSV. Errors found while
+// decompressing the data are returned on the DataFrame.
+func ReadCSVGzip(r io.Reader, options ...LoadOption) GotaDataFrame {
+	gz, err := gzip.NewReader(r)
 	if err != nil {
-		return GotaDataFrame{Err: err}
+		return GotaDataFrame{Err: fmt.Errorf("read csv gzip: %v", err)}
+	}
+	defer gz.Close()
+	df := ReadCSV(gz, options...)
+	if df.Err != nil {
+		df.Err = fmt.Errorf("read csv gzip: %v", df.Err)
+	}
+	return df
+}
+
+// ReadTSV reads a tab-separated file from a io.Reader and builds a DataFrame
+// with the resulting records. It accepts the same options as ReadCSV, except
+// that the delimiter is always a tab.
+func ReadTSV(r io.Reader, options ...LoadOption) GotaDataFrame {
+	tsvOptions := make([]LoadOption, 0, len(options)+1)
+	tsvOptions = append(tsvOptions, options...)
+	tsvOptions = append(tsvOptions, WithDelimiter('\t'))
+	return ReadCSV(r, tsvOptions...)
+}
+
+// ReadFWF reads a fixed-width formatted file from a io.Reader and builds a
+// DataFrame with the resulting records. Every line is split in fields of the
+// given widths, in characters, which are trimmed of surrounding white space.
+// Blank lines, as well as lines starting with the WithComments rune, are
+// skipped.
+func ReadFWF(r io.Reader, widths []int, options ...LoadOption) GotaDataFrame {
+	cfg := loadOptions{}
+	for _, option := range options {
+		option(&cfg)
+	}
+	if len(widths) == 0 {
+		return GotaDataFrame{Err: fmt.Errorf("read fwf: no column widths given")}
+	}
+	total := 0
+	for _, w := range widths {
+		if w <= 0 {
+			return GotaDataFrame{Err: fmt.Errorf("read fwf: invalid column width %d", w)}
+		}
+		total += w
+	}
+
+	var records [][]string
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := []rune(strings.TrimRight(scanner.Text(), "\r"))
+		if strings.TrimSpace(string(line)) == "" || (cfg.comment != 0 && line[0] == cfg.comment) {
+			continue
+		}
+		if cfg.strictWidths && len(line) < total {
+			return GotaDataFrame{Err: fmt.Errorf("read fwf: line %d: expected %d characters, got %d", n, total, len(line))}
+		}
+		record := make([]string, len(widths))
+		start := 0
+		for i, w := range widths {
+			end := start + w
+			if start < len(line) {
+				record[i] = strings.TrimSpace(string(line[start:min(end, len(line))]))
+			}
+			start = end
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return GotaDataFrame{Err: fmt.Errorf("read fwf: %v", err)}
 	}
 	return LoadRecords(records, options...)
 }
 
+// ReadCSVStream reads a CSV file from a io.Reader in batches of up to batch
+// rows, calling fn with a DataFrame for each of them, so that files too large
+// to fit in memory can be processed. The column names and types are detected
+// on the first batch and applied to the rest of them. SkipRows and SkipFooter
+// apply to the whole file, not to each batch. Reading stops at the first error
+// returned by fn, which is returned by ReadCSVStream.
+func ReadCSVStream(r io.Reader, batch int, fn func(GotaDataFrame) error, options ...LoadOption) error {
+	if batch <= 0 {
+		return fmt.Errorf("read csv stream: batch size must be positive")
+	}
+	cfg := loadOptions{
+		delimiter:  ',',
+		lazyQuotes: false,
+		comment:    0,
+		hasHeader:  true,
+	}
+	for _, option := range options {
+		option(&cfg)
+	}
+
+	br := bufio.NewReader(r)
+	for i := 0; i < cfg.skipRows; i++ {
+		if _, err := br.ReadString('\n'); err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+	}
+	csvReader := csv.NewReader(br)
+	csvReader.Comma = cfg.delimiter
+	csvReader.LazyQuotes = cfg.lazyQuotes
+	csvReader.Comment = cfg.comment
+	if cfg.skipFooter > 0 {
+		// The footer can have a different number of fields, so they are
+		// checked after removing it
+		csvReader.FieldsPerRecord = -1
+	}
+
+	var header []string
+	if cfg.hasHeader {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		header = record
+	}
+
+	// The rows have already been skipped
+	streamOptions := make([]LoadOption, 0, len(options)+2)
+	streamOptions = append(streamOptions, options...)
+	streamOptions = append(streamOptions, SkipRows(0), SkipFooter(0))
+
+	// The options of the batches after the first one, with the detected names
+	// and types.
+	var batchOptions []LoadOption
+	// The rows read but not loaded yet. The last skipFooter of them are held
+	// back until the end of the file, as they might be the footer.
+	var pending [][]string
+	eof := false
+	// The number of rows loaded so far, to report those with a wrong number
+	// of fields.
+	nread := 0
+	for {
+		for !eof && len(pending) < batch+cfg.skipFooter {
+			record, err := csvReader.Read()
+			if err == io.EOF {
+				eof = true
+				break
+			}
+			if err != nil {
+				return err
+			}
+			pending = append(pending, record)
+		}
+		n := min(batch, len(pending))
+		if eof {
+			n = min(batch, max(len(pending)-cfg.skipFooter, 0))
+		}
+		if n == 0 {
+			return nil
+		}
+
+		records := make([][]string, 0, n+1)
+		if header != nil {
+			records = append(records, header)
+		}
+		records = append(records, pending[:n]...)
+		if cfg.skipFooter > 0 {
+			for i, record := range pending[:n] {
+				if len(record) != len(records[0]) {
+					return fmt.Errorf("read csv stream: row %d has %d fields, expected %d", nread+i+1, len(record), len(records[0]))
+				}
+			}
+		}
+		pending = pending[n:]
+		nread += n
+
+		var df GotaDataFrame
+		if batchOptions == nil {
+			df = LoadRecords(records, streamOptions...)
+			if df.Err != nil {
+				return df.Err
+			}
+			names := df.Names()
+			types := make(map[string]series.Type, len(names))
+			for i, t := range df.Types() {
+				types[names[i]] = t
+			}
+			header = names
+			batchOptions = append(append([]LoadOption{}, streamOptions...), HasHeader(true), Names(names...), WithTypes(types))
+		} else {
+			df = LoadRecords(records, batchOptions...)
+			if df.Err != nil {
+				return df.Err
+			}
+		}
+		if err := fn(df); err != nil {
+			return err
+		}
+	}
+}
+
 // ReadJSON reads a JSON array from a io.Reader and builds a DataFrame with the
 // resulting records.
 func ReadJSON(r io.Reader, options ...LoadOption) DataFrame {
+	cfg := loadOptions{}
+	for _, option := range options {
+		option(&cfg)
+	}
+	if cfg.preserveOrder {
+		var raws []json.RawMessage
+		if err := json.NewDecoder(r).Decode(&raws); err != nil {
+			return GotaDataFrame{Err: err}
+		}
+		m := make([]map[string]interface{}, len(raws))
+		var keys []string
+		for i, raw := range raws {
+			var err error
+			m[i], keys, err = decodeJSONObject(raw, keys, cfg)
+			if err != nil {
+				return GotaDataFrame{Err: err}
+			}
+		}
+		jsonOptions := make([]LoadOption, 0, len(options)+1)
+		jsonOptions = append(jsonOptions, options...)
+		jsonOptions = append(jsonOptions, keyOrder(keys))
+		return LoadMaps(m, jsonOptions...)
+	}
+
 	var m []map[string]interface{}
 	d := json.NewDecoder(r)
 	d.UseNumber()
@@ -461,12 +1081,150 @@ func ReadJSON(r io.Reader, options ...LoadOption) DataFrame {
 	return LoadMaps(m, options...)
 }
 
+// decodeJSONObject decodes the given JSON object and, if the preserveOrder
+// option is set, appends to keys the names of the columns it results in that
+// are not already present, in order of appearance.
+func decodeJSONObject(raw []byte, keys []string, cfg loadOptions) (map[string]interface{}, []string, error) {
+	var m map[string]interface{}
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+	if err := d.Decode(&m); err != nil {
+		return nil, keys, err
+	}
+	if d.More() {
+		return nil, keys, fmt.Errorf("unexpected data after object")
+	}
+	if !cfg.preserveOrder || m == nil {
+		return m, keys, nil
+	}
+
+	seen := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		seen[k] = true
+	}
+	add := func(k string) {
+		if !seen[k] {
+			seen[k] = true
+			keys = append(keys, k)
+		}
+	}
+	d = json.NewDecoder(bytes.NewReader(raw))
+	if err := walkJSONKeys(d, "", true, cfg, add); err != nil {
+		return nil, keys, err
+	}
+	return m, keys, nil
+}
+
+// walkJSONKeys reads the next JSON value from the decoder and calls add with
+// the column name of each of its keys, in order. Nested values are only
+// descended into on the top level object or if the flatten option is set, in
+// which case the names are built as in FlattenJSON.
+func walkJSONKeys(d *json.Decoder, prefix string, top bool, cfg loadOptions, add func(string)) error {
+	join := func(k string) string {
+		if prefix == "" {
+			return k
+		}
+		return prefix + cfg.flattenSep + k
+	}
+	if !top && !cfg.flatten {
+		add(prefix)
+		var skip json.RawMessage
+		return d.Decode(&skip)
+	}
+	tok, err := d.Token()
+	if err != nil {
+		return err
+	}
+	switch tok {
+	case json.Delim('{'):
+		for d.More() {
+			key, err := d.Token()
+			if err != nil {
+				return err
+			}
+			if err := walkJSONKeys(d, join(key.(string)), false, cfg, add); err != nil {
+				return err
+			}
+		}
+	case json.Delim('['):
+		for i := 0; d.More(); i++ {
+			if err := walkJSONKeys(d, join(strconv.Itoa(i)), false, cfg, add); err != nil {
+				return err
+			}
+		}
+	default:
+		add(prefix)
+		return nil
+	}
+	// Consume the closing delimiter
+	_, err = d.Token()
+	return err
+}
+
+// ReadJSONLines reads newline-delimited JSON from a io.Reader, where every
+// non-blank line holds a JSON object, and builds a DataFrame with the resulting
+// records as in LoadMaps.
+func ReadJSONLines(r io.Reader, options ...LoadOption) DataFrame {
+	cfg := loadOptions{}
+	for _, option := range options {
+		option(&cfg)
+	}
+	var maps []map[string]interface{}
+	var keys []string
+	br := bufio.NewReader(r)
+	for n := 1; ; n++ {
+		line, err := br.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return GotaDataFrame{Err: fmt.Errorf("read json lines: line %d: %v", n, err)}
+		}
+		if len(bytes.TrimSpace(line)) > 0 {
+			m, newKeys, derr := decodeJSONObject(line, keys, cfg)
+			if derr != nil {
+				return GotaDataFrame{Err: fmt.Errorf("read json lines: line %d: %v", n, derr)}
+			}
+			maps = append(maps, m)
+			keys = newKeys
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+	jsonOptions := make([]LoadOption, 0, len(options)+1)
+	jsonOptions = append(jsonOptions, options...)
+	if cfg.preserveOrder {
+		jsonOptions = append(jsonOptions, keyOrder(keys))
+	}
+	return LoadMaps(maps, jsonOptions...)
+}
+
 // WriteOption is the type used to configure the writing of elements
 type WriteOption func(*writeOptions)
 
 type writeOptions struct {
 	// Specifies whether the header is also written
 	writeHeader bool
+
+	// The representation of NaN elements
+	naRepr string
+
+	// The names of the columns to write, in order
+	columns []string
+
+	// Specifies the number of rows inserted by each SQL statement
+	batchSize int
+
+	// Specifies whether the SQL table is created before inserting the rows
+	createTable bool
+
+	// The SQL column types used when creating a table, by Series type
+	sqlTypes map[series.Type]string
+
+	// The compression codec used when writing columnar formats
+	compression Compression
+
+	// The class and id attributes of the HTML table element
+	htmlClass string
+	htmlID    string
 }
 
 // WriteHeader sets the writeHeader option for writeOptions.
@@ -476,6 +1234,22 @@ func WriteHeader(b bool) WriteOption {
 	}
 }
 
+// NARepr sets the naRepr option for writeOptions, the string written for NaN
+// elements. It defaults to "NaN".
+func NARepr(s string) WriteOption {
+	return func(c *writeOptions) {
+		c.naRepr = s
+	}
+}
+
+// Columns sets the columns option for writeOptions, restricting the written
+// columns to the given ones, in the given order.
+func Columns(names ...string) WriteOption {
+	return func(c *writeOptions) {
+		c.columns = names
+	}
+}
+
 // WriteCSV writes the DataFrame to the given io.Writer as a CSV file.
 func (df GotaDataFrame) WriteCSV(w io.Writer, options ...WriteOption) error {
 	if df.Err != nil {
@@ -485,6 +1259,7 @@ func (df GotaDataFrame) WriteCSV(w io.Writer, options ...WriteOption) error {
 	// Set the default write options
 	cfg := writeOptions{
 		writeHeader: true,
+		naRepr:      "NaN",
 	}
 
 	// Set any custom write options
@@ -493,6 +1268,29 @@ func (df GotaDataFrame) WriteCSV(w io.Writer, options ...WriteOption) error {
 	}
 
 	records := df.Records()
+	for j, col := range df.columns {
+		for i, isNaN := range col.IsNaN() {
+			if isNaN {
+				records[i+1][j] = cfg.naRepr
+			}
+		}
+	}
+	if cfg.columns != nil {
+		idx := make([]int, len(cfg.columns))
+		for k, name := range cfg.columns {
+			idx[k] = df.ColIndex(name)
+			if idx[k] < 0 {
+				return fmt.Errorf("write csv: unknown column name %s", name)
+			}
+		}
+		for i, record := range records {
+			selected := make([]string, len(idx))
+			for k, j := range idx {
+				selected[k] = record[j]
+			}
+			records[i] = selected
+		}
+	}
 	if !cfg.writeHeader {
 		records = records[1:]
 	}
@@ -508,6 +1306,116 @@ func (df GotaDataFrame) WriteJSON(w io.Writer) error {
 	return json.NewEncoder(w).Encode(df.Maps())
 }
 
+// FillStructs sets out, which must be a pointer to a slice of structs, to a
+// slice with one struct per row of the DataFrame. The columns are matched with
+// the fields of the structs by name, following the same rules as LoadStructs,
+// and their elements are converted to the types of the fields. NaN elements
+// leave the fields with their zero values. Every field not ignored with the
+// `dataframe:"-"` struct tag must have a matching column.
+func (df GotaDataFrame) FillStructs(out interface{}) error {
+	if df.Err != nil {
+		return df.Err
+	}
+	ptr := reflect.ValueOf(out)
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() ||
+		ptr.Elem().Kind() != reflect.Slice || ptr.Elem().Type().Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("fill structs: type %T is not supported, must be *[]struct", out)
+	}
+	sliceType := ptr.Elem().Type()
+	fields, err := structFields(sliceType.Elem(), nil, "")
+	if err != nil {
+		return fmt.Errorf("fill structs: %v", err)
+	}
+	colidx := make([]int, len(fields))
+	for k, field := range fields {
+		colidx[k] = df.ColIndex(field.name)
+		if colidx[k] == -1 {
+			return fmt.Errorf("fill structs: no column %q for field %s",
+				field.name, sliceType.Elem().FieldByIndex(field.index).Name)
+		}
+	}
+
+	structs := reflect.MakeSlice(sliceType, df.nrows, df.nrows)
+	for i := 0; i < df.nrows; i++ {
+		for k, field := range fields {
+			elem := df.columns[colidx[k]].Elem(i)
+			if elem.IsNA() {
+				continue
+			}
+			if err := setStructField(structs.Index(i).FieldByIndex(field.index), elem); err != nil {
+				return fmt.Errorf("fill structs: row %d, column %q: %v", i, field.name, err)
+			}
+		}
+	}
+	ptr.Elem().Set(structs)
+	return nil
+}
+
+// setStructField sets the struct field v to the value of the given non NaN
+// Element, converted to the type of the field.
+func setStructField(v reflect.Value, elem series.Element) error {
+	if v.Kind() == reflect.Ptr {
+		p := reflect.New(v.Type().Elem())
+		if err := setStructField(p.Elem(), elem); err != nil {
+			return err
+		}
+		v.Set(p)
+		return nil
+	}
+	if v.Type() == timeType {
+		t, ok := elem.Val().(time.Time)
+		if !ok {
+			parsed, err := time.Parse(series.TimeFormat, elem.String())
+			if err != nil {
+				return fmt.Errorf("can't convert %q to time.Time", elem.String())
+			}
+			t = parsed
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(elem.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := elem.Int()
+		if err != nil {
+			return err
+		}
+		if v.OverflowInt(int64(n)) {
+			return fmt.Errorf("value %d overflows %s", n, v.Type())
+		}
+		v.SetInt(int64(n))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		// Uint elements can exceed the range of int, so they are parsed from
+		// their string representation
+		n, err := strconv.ParseUint(elem.String(), 10, 64)
+		if err != nil {
+			i, err := elem.Int()
+			if err != nil || i < 0 {
+				return fmt.Errorf("can't convert %q to %s", elem.String(), v.Type())
+			}
+			n = uint64(i)
+		}
+		if v.OverflowUint(n) {
+			return fmt.Errorf("value %d overflows %s", n, v.Type())
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(elem.Float())
+	case reflect.Bool:
+		b, err := elem.Bool()
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	default:
+		return fmt.Errorf("type %s is not supported", v.Type())
+	}
+	return nil
+}
+
 // Internal state for implementing ReadHTML
 type remainder struct {
 	index int
@@ -632,3 +1540,69 @@ func ReadHTML(r io.Reader, options ...LoadOption) []GotaDataFrame {
 	f(doc)
 	return dfs
 }
+
+// WithHTMLClass sets the class attribute of the table written by WriteHTML.
+func WithHTMLClass(class string) WriteOption {
+	return func(c *writeOptions) {
+		c.htmlClass = class
+	}
+}
+
+// WithHTMLID sets the id attribute of the table written by WriteHTML.
+func WithHTMLID(id string) WriteOption {
+	return func(c *writeOptions) {
+		c.htmlID = id
+	}
+}
+
+// WriteHTML writes the DataFrame to the given io.Writer as an HTML table, with
+// the column names on its <thead> and the rows on its <tbody>. The contents of
+// the cells are HTML-escaped.
+func (df GotaDataFrame) WriteHTML(w io.Writer, options ...WriteOption) error {
+	if df.Err != nil {
+		return df.Err
+	}
+
+	// Set the default write options
+	cfg := writeOptions{
+		writeHeader: true,
+	}
+
+	// Set any custom write options
+	for _, option := range options {
+		option(&cfg)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<table")
+	if cfg.htmlID != "" {
+		sb.WriteString(` id="` + html.EscapeString(cfg.htmlID) + `"`)
+	}
+	if cfg.htmlClass != "" {
+		sb.WriteString(` class="` + html.EscapeString(cfg.htmlClass) + `"`)
+	}
+	sb.WriteString(">\n")
+
+	writeRow := func(record []string, tag string) {
+		sb.WriteString("    <tr>")
+		for _, cell := range record {
+			sb.WriteString("<" + tag + ">" + html.EscapeString(cell) + "</" + tag + ">")
+		}
+		sb.WriteString("</tr>\n")
+	}
+
+	records := df.Records()
+	if cfg.writeHeader {
+		sb.WriteString("  <thead>\n")
+		writeRow(records[0], "th")
+		sb.WriteString("  </thead>\n")
+	}
+	sb.WriteString("  <tbody>\n")
+	for _, record := range records[1:] {
+		writeRow(record, "td")
+	}
+	sb.WriteString("  </tbody>\n</table>\n")
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,266 @@
+package dataframe
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/go-gota/gota/series"
+	"github.com/parquet-go/parquet-go"
+)
+
+// parquetColumnsKey is the key of the Parquet metadata entry where the original
+// order of the columns of a DataFrame is stored, since Parquet schemas sort
+// their fields by name.
+const parquetColumnsKey = "gota.columns"
+
+// ReadParquet reads a Parquet file from a io.Reader and builds a DataFrame with
+// its columns. Boolean columns are loaded as Bool, integer columns as Int,
+// floating point columns as Float and byte array columns as String, unless
+// otherwise specified with the WithTypes option. Null values are loaded as NaN.
+// Since they are passed to LoadRecords as the string "NaN", byte array values
+// equal to "NaN" are loaded as NaN too. Only flat schemas are supported.
+func ReadParquet(r io.Reader, options ...LoadOption) GotaDataFrame {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return GotaDataFrame{Err: fmt.Errorf("read parquet: %v", err)}
+	}
+	f, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return GotaDataFrame{Err: fmt.Errorf("read parquet: %v", err)}
+	}
+
+	schema := f.Schema()
+	paths := schema.Columns()
+	colnames := make([]string, len(paths))
+	types := make(map[string]series.Type, len(paths))
+	for i, path := range paths {
+		if len(path) != 1 {
+			return GotaDataFrame{Err: fmt.Errorf("read parquet: nested column %v is not supported", path)}
+		}
+		leaf, _ := schema.Lookup(path...)
+		t, err := parquetSeriesType(leaf.Node.Type().Kind())
+		if err != nil {
+			return GotaDataFrame{Err: fmt.Errorf("read parquet: column %s: %v", path[0], err)}
+		}
+		colnames[i] = path[0]
+		types[path[0]] = t
+	}
+
+	records := [][]string{colnames}
+	buf := make([]parquet.Row, 128)
+	for _, rg := range f.RowGroups() {
+		rows := rg.Rows()
+		for {
+			n, err := rows.ReadRows(buf)
+			for _, row := range buf[:n] {
+				record := make([]string, len(colnames))
+				for _, v := range row {
+					record[v.Column()] = parquetValueToString(v)
+				}
+				records = append(records, record)
+			}
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				rows.Close()
+				return GotaDataFrame{Err: fmt.Errorf("read parquet: %v", err)}
+			}
+		}
+		rows.Close()
+	}
+
+	// Null values are encoded as "NaN", which is the only NaN value unless
+	// otherwise specified, so strings like "NA" are loaded verbatim. Byte
+	// array values equal to "NaN" can't be told apart from null values.
+	options = append([]LoadOption{NaNValues([]string{"NaN"}), WithTypes(types)}, options...)
+	df := LoadRecords(records, options...)
+	if df.Err != nil {
+		return df
+	}
+
+	// Restore the original order of the columns if it was stored by WriteParquet
+	if value, ok := f.Lookup(parquetColumnsKey); ok {
+		var order []string
+		if err := json.Unmarshal([]byte(value), &order); err == nil && len(order) == df.ncols {
+			if ordered, ok := df.Select(order).(GotaDataFrame); ok && ordered.Err == nil {
+				return ordered
+			}
+		}
+	}
+	return df
+}
+
+// parquetSeriesType returns the Series type used to load a Parquet column of
+// the given kind.
+func parquetSeriesType(kind parquet.Kind) (series.Type, error) {
+	switch kind {
+	case parquet.Boolean:
+		return series.Bool, nil
+	case parquet.Int32, parquet.Int64:
+		return series.Int, nil
+	case parquet.Float, parquet.Double:
+		return series.Float, nil
+	case parquet.ByteArray, parquet.FixedLenByteArray:
+		return series.String, nil
+	default:
+		return "", fmt.Errorf("type %s is not supported", kind)
+	}
+}
+
+// parquetValueToString returns the string representation of a Parquet value.
+func parquetValueToString(v parquet.Value) string {
+	if v.IsNull() {
+		return "NaN"
+	}
+	switch v.Kind() {
+	case parquet.Boolean:
+		return strconv.FormatBool(v.Boolean())
+	case parquet.Int32:
+		return strconv.FormatInt(int64(v.Int32()), 10)
+	case parquet.Int64:
+		return strconv.FormatInt(v.Int64(), 10)
+	case parquet.Float:
+		return strconv.FormatFloat(float64(v.Float()), 'f', -1, 32)
+	case parquet.Double:
+		return strconv.FormatFloat(v.Double(), 'f', -1, 64)
+	case parquet.ByteArray, parquet.FixedLenByteArray:
+		return string(v.ByteArray())
+	default:
+		return v.String()
+	}
+}
+
+// Compression is the compression codec used to write columnar file formats.
+type Compression int
+
+// Supported Compression codecs
+const (
+	CompressionSnappy Compression = iota // Snappy compression, the default
+	CompressionGzip                      // Gzip compression
+	CompressionNone                      // No compression
+)
+
+// WithCompression sets the compression codec for writeOptions.
+func WithCompression(c Compression) WriteOption {
+	return func(o *writeOptions) {
+		o.compression = c
+	}
+}
+
+// WriteParquet writes the DataFrame to the given io.Writer as a Parquet file.
+// Every column is written as an optional Parquet column: String as UTF-8
+// strings, Int as 64 bit integers, Float as doubles and Bool as booleans. NaN
+// elements are written as null.
+func (df GotaDataFrame) WriteParquet(w io.Writer, options ...WriteOption) error {
+	if df.Err != nil {
+		return df.Err
+	}
+
+	// Set the default write options
+	cfg := writeOptions{
+		compression: CompressionSnappy,
+	}
+
+	// Set any custom write options
+	for _, option := range options {
+		option(&cfg)
+	}
+
+	var codec parquet.WriterOption
+	switch cfg.compression {
+	case CompressionSnappy:
+		codec = parquet.Compression(&parquet.Snappy)
+	case CompressionGzip:
+		codec = parquet.Compression(&parquet.Gzip)
+	case CompressionNone:
+		codec = parquet.Compression(&parquet.Uncompressed)
+	default:
+		return fmt.Errorf("write parquet: unknown compression %d", cfg.compression)
+	}
+
+	group := parquet.Group{}
+	for _, col := range df.columns {
+		var node parquet.Node
+		switch col.Type() {
+		case series.String:
+			node = parquet.String()
+		case series.Int:
+			node = parquet.Int(64)
+		case series.Float:
+			node = parquet.Leaf(parquet.DoubleType)
+		case series.Bool:
+			node = parquet.Leaf(parquet.BooleanType)
+		default:
+			return fmt.Errorf("write parquet: type %s of column %s is not supported", col.Type(), col.Name)
+		}
+		group[col.Name] = parquet.Optional(node)
+	}
+	schema := parquet.NewSchema("gota", group)
+
+	// Parquet groups sort their fields by name, so the original order is stored
+	// to be restored by ReadParquet.
+	order, err := json.Marshal(df.Names())
+	if err != nil {
+		return fmt.Errorf("write parquet: %v", err)
+	}
+	writer := parquet.NewWriter(w, schema, codec, parquet.KeyValueMetadata(parquetColumnsKey, string(order)))
+
+	colIndexes := make([]int, df.ncols)
+	for j, col := range df.columns {
+		leaf, _ := schema.Lookup(col.Name)
+		colIndexes[j] = leaf.ColumnIndex
+	}
+	rows := make([]parquet.Row, df.nrows)
+	for i := range rows {
+		row := make(parquet.Row, df.ncols)
+		for j, col := range df.columns {
+			v, err := parquetValue(col.Elem(i), col.Type())
+			if err != nil {
+				return fmt.Errorf("write parquet: %v", err)
+			}
+			definitionLevel := 1
+			if v.IsNull() {
+				definitionLevel = 0
+			}
+			row[colIndexes[j]] = v.Level(0, definitionLevel, colIndexes[j])
+		}
+		rows[i] = row
+	}
+	if _, err := writer.WriteRows(rows); err != nil {
+		return fmt.Errorf("write parquet: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("write parquet: %v", err)
+	}
+	return nil
+}
+
+// parquetValue returns the Parquet value of an Element. NaN elements are
+// returned as null values.
+func parquetValue(e series.Element, t series.Type) (parquet.Value, error) {
+	if e.IsNA() {
+		return parquet.NullValue(), nil
+	}
+	switch t {
+	case series.Int:
+		i, err := e.Int()
+		if err != nil {
+			return parquet.Value{}, err
+		}
+		return parquet.ValueOf(int64(i)), nil
+	case series.Float:
+		return parquet.ValueOf(e.Float()), nil
+	case series.Bool:
+		b, err := e.Bool()
+		if err != nil {
+			return parquet.Value{}, err
+		}
+		return parquet.ValueOf(b), nil
+	default:
+		return parquet.ValueOf(e.String()), nil
+	}
+}
@@ -0,0 +1,180 @@
+package dataframe
+
+import (
+	"fmt"
+
+	"github.com/go-gota/gota/series"
+)
+
+// Reshaping methods
+// =================
+
+// Pivot reshapes a DataFrame from long to wide format. The resulting DataFrame
+// has one row per distinct value of the `index` column and one column per
+// distinct value of the `columns` column, in order of appearance. Every cell
+// holds the matching element of the `values` column, or NaN if the combination
+// is not present. Duplicated index/column pairs are reported as an error.
+func (df GotaDataFrame) Pivot(index, columns, values string) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	for _, colname := range []string{index, columns, values} {
+		if df.ColIndex(colname) < 0 {
+			return GotaDataFrame{Err: fmt.Errorf("pivot: can't find column name %q", colname)}
+		}
+	}
+	idxCol := df.columns[df.ColIndex(index)]
+	keyCol := df.columns[df.ColIndex(columns)]
+	valCol := df.columns[df.ColIndex(values)]
+
+	idxRecords := idxCol.Records()
+	keyRecords := keyCol.Records()
+	rows, rowPos := distinctRecords(idxRecords)
+	keys, keyPos := distinctRecords(keyRecords)
+
+	cells := make([][]interface{}, len(keys))
+	filled := make([][]bool, len(keys))
+	for k := range keys {
+		cells[k] = make([]interface{}, len(rows))
+		filled[k] = make([]bool, len(rows))
+	}
+	for i := 0; i < df.nrows; i++ {
+		r, k := rowPos[idxRecords[i]], keyPos[keyRecords[i]]
+		if filled[k][r] {
+			return GotaDataFrame{Err: fmt.Errorf(
+				"pivot: duplicated entry for index %q and column %q", idxRecords[i], keyRecords[i])}
+		}
+		filled[k][r] = true
+		cells[k][r] = valCol.Elem(i)
+	}
+
+	newCols := []series.Series1{idxCol.Subset(rows)}
+	for k, i := range keys {
+		newCols = append(newCols, series.New(cells[k], valCol.Type(), keyRecords[i]))
+	}
+	return New(newCols...)
+}
+
+// PivotTable reshapes a DataFrame from long to wide format like Pivot, but the
+// elements of the `values` column sharing the same index/column pair are
+// reduced with the given aggregation instead of being reported as an error.
+// The cells of the resulting DataFrame are Float, as in Groups.Aggregation, or
+// NaN if the combination is not present.
+func (df GotaDataFrame) PivotTable(index, columns, values string, agg AggregationType) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	for _, colname := range []string{index, columns, values} {
+		if df.ColIndex(colname) < 0 {
+			return GotaDataFrame{Err: fmt.Errorf("pivot table: can't find column name %q", colname)}
+		}
+	}
+	idxCol := df.columns[df.ColIndex(index)]
+	keyCol := df.columns[df.ColIndex(columns)]
+	valCol := df.columns[df.ColIndex(values)]
+
+	idxRecords := idxCol.Records()
+	keyRecords := keyCol.Records()
+	rows, rowPos := distinctRecords(idxRecords)
+	keys, keyPos := distinctRecords(keyRecords)
+
+	members := make([][][]int, len(keys))
+	for k := range keys {
+		members[k] = make([][]int, len(rows))
+	}
+	for i := 0; i < df.nrows; i++ {
+		r, k := rowPos[idxRecords[i]], keyPos[keyRecords[i]]
+		members[k][r] = append(members[k][r], i)
+	}
+
+	newCols := []series.Series1{idxCol.Subset(rows)}
+	for k, i := range keys {
+		cells := make([]interface{}, len(rows))
+		for r, idx := range members[k] {
+			if len(idx) == 0 {
+				continue
+			}
+			value, err := aggregate(valCol.Subset(idx), agg)
+			if err != nil {
+				return GotaDataFrame{Err: fmt.Errorf("pivot table: %v", err)}
+			}
+			cells[r] = value
+		}
+		newCols = append(newCols, series.New(cells, series.Float, keyRecords[i]))
+	}
+	return New(newCols...)
+}
+
+// distinctRecords returns the position of the first appearance of every
+// distinct record, along with the rank of each record on that list.
+func distinctRecords(records []string) ([]int, map[string]int) {
+	var first []int
+	pos := make(map[string]int)
+	for i, r := range records {
+		if _, ok := pos[r]; !ok {
+			pos[r] = len(first)
+			first = append(first, i)
+		}
+	}
+	return first, pos
+}
+
+// Melt reshapes a DataFrame from wide to long format. The columns in valueVars
+// are unpivoted into two columns, named after varName and valueName, holding
+// the original column name and value respectively, while the idVars columns
+// are repeated for every melted column. If valueVars is empty, all the columns
+// not in idVars are melted. The type of the value column is the type of the
+// melted columns, or String if they have different types.
+func (df GotaDataFrame) Melt(idVars []string, valueVars []string, varName, valueName string) DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	idIdx, err := parseSelectIndexes(df.ncols, idVars, df.Names())
+	if err != nil {
+		return GotaDataFrame{Err: fmt.Errorf("melt: %v", err)}
+	}
+	if len(valueVars) == 0 {
+		for _, colname := range df.Names() {
+			if findInStringSlice(colname, idVars) == -1 {
+				valueVars = append(valueVars, colname)
+			}
+		}
+	}
+	valIdx, err := parseSelectIndexes(df.ncols, valueVars, df.Names())
+	if err != nil {
+		return GotaDataFrame{Err: fmt.Errorf("melt: %v", err)}
+	}
+	if len(valIdx) == 0 {
+		return GotaDataFrame{Err: fmt.Errorf("melt: no columns to melt")}
+	}
+
+	valType := df.columns[valIdx[0]].Type()
+	for _, j := range valIdx[1:] {
+		if df.columns[j].Type() != valType {
+			valType = series.String
+			break
+		}
+	}
+
+	n := df.nrows * len(valIdx)
+	rowIdx := make([]int, 0, n)
+	variables := make([]string, 0, n)
+	values := make([]interface{}, 0, n)
+	for _, j := range valIdx {
+		for i := 0; i < df.nrows; i++ {
+			rowIdx = append(rowIdx, i)
+			variables = append(variables, df.columns[j].Name)
+			values = append(values, df.columns[j].Elem(i))
+		}
+	}
+
+	var newCols []series.Series1
+	for _, i := range idIdx {
+		newCols = append(newCols, df.columns[i].Subset(rowIdx))
+	}
+	newCols = append(newCols,
+		series.New(variables, series.String, varName),
+		series.New(values, valType, valueName),
+	)
+	return New(newCols...)
+}
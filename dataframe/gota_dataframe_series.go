@@ -0,0 +1,79 @@
+package dataframe
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/go-gota/gota/series"
+)
+
+// ValueCounts returns a DataFrame with the distinct values of the given Series,
+// in a column named "values" of the same type as the Series, and the number of
+// times each of them appears, in an Int column named "counts". The rows are
+// sorted as in Series.ValueCounts, in descending order of count, and NaN
+// elements are counted as a category of their own unless dropNA is given as
+// true.
+func ValueCounts[T series.SeriesType](s series.Series[T], dropNA ...bool) DataFrame {
+	if err := s.Error(); err != nil {
+		return GotaDataFrame{Err: fmt.Errorf("value counts: %v", err)}
+	}
+	values, counts := s.ValueCounts(dropNA...)
+	return New(
+		seriesColumn(values, "values"),
+		seriesColumn(counts, "counts"),
+	)
+}
+
+// DescribeSeries returns a DataFrame with the summary statistics of the given
+// Series, as computed by Series.Describe, with the name of each statistic in a
+// String column named "statistic" and its value in a String column named
+// "value".
+func DescribeSeries[T series.SeriesType](s series.Series[T]) DataFrame {
+	if err := s.Error(); err != nil {
+		return GotaDataFrame{Err: fmt.Errorf("describe series: %v", err)}
+	}
+	statistics, values := s.Describe()
+	return New(
+		seriesColumn(statistics, "statistic"),
+		seriesColumn(values, "value"),
+	)
+}
+
+// seriesColumn converts the given Series to a DataFrame column with the given
+// name. NaN elements are kept as NaN.
+func seriesColumn[T series.SeriesType](s series.Series[T], name string) series.Series1 {
+	var zero T
+	var t series.Type
+	switch reflect.TypeOf(zero).Kind() {
+	case reflect.String:
+		t = series.String
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		t = series.Int
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		t = series.Uint
+	default:
+		t = series.Float
+	}
+
+	elements := make([]interface{}, s.Len())
+	for i := range elements {
+		e := s.Elem(i)
+		if e.IsNA() {
+			continue
+		}
+		v := reflect.ValueOf(e.Val())
+		switch t {
+		case series.String:
+			elements[i] = v.String()
+		case series.Int:
+			elements[i] = int(v.Int())
+		case series.Uint:
+			// Uint columns are parsed from their string representation
+			elements[i] = strconv.FormatUint(v.Uint(), 10)
+		default:
+			elements[i] = v.Float()
+		}
+	}
+	return series.New(elements, t, name)
+}
@@ -0,0 +1,224 @@
+package dataframe
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-gota/gota/series"
+)
+
+// ReadSQL builds a DataFrame from the result of a SQL query. The column names
+// are taken from the query result and the column types are detected from the
+// scanned values, as with LoadRecords. SQL NULL values are loaded as NaN. Since
+// they are passed to LoadRecords as the string "NaN", text values equal to
+// "NaN" are loaded as NaN too.
+//
+// ReadSQL consumes rows but doesn't close them on error; it is the caller's
+// responsibility to do so.
+func ReadSQL(rows *sql.Rows, options ...LoadOption) GotaDataFrame {
+	if rows == nil {
+		return GotaDataFrame{Err: fmt.Errorf("read sql: rows is nil")}
+	}
+	colnames, err := rows.Columns()
+	if err != nil {
+		return GotaDataFrame{Err: fmt.Errorf("read sql: %v", err)}
+	}
+
+	records := [][]string{colnames}
+	values := make([]interface{}, len(colnames))
+	pointers := make([]interface{}, len(colnames))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return GotaDataFrame{Err: fmt.Errorf("read sql: %v", err)}
+		}
+		record := make([]string, len(values))
+		for i, v := range values {
+			record[i] = sqlValueToString(v)
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return GotaDataFrame{Err: fmt.Errorf("read sql: %v", err)}
+	}
+
+	// NULL values are encoded as "NaN", which is the only NaN value unless
+	// otherwise specified, so strings like "NA" are loaded verbatim. Text
+	// values equal to "NaN" can't be told apart from NULL values.
+	options = append([]LoadOption{NaNValues([]string{"NaN"})}, options...)
+	return LoadRecords(records, options...)
+}
+
+// sqlValueToString returns the string representation of a value scanned from
+// a SQL row.
+func sqlValueToString(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return "NaN"
+	case []byte:
+		return string(val)
+	case string:
+		return val
+	case int64:
+		return strconv.FormatInt(val, 10)
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(val)
+	case time.Time:
+		return val.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// DefaultSQLTypes are the SQL column types used by WriteSQL to create a table
+// when no other types are specified with WithSQLTypes.
+var DefaultSQLTypes = map[series.Type]string{
+	series.String: "TEXT",
+	series.Int:    "INTEGER",
+	series.Float:  "REAL",
+	series.Bool:   "BOOLEAN",
+}
+
+// WithBatchSize sets the number of rows inserted by each statement of WriteSQL.
+func WithBatchSize(n int) WriteOption {
+	return func(c *writeOptions) {
+		c.batchSize = n
+	}
+}
+
+// CreateTable sets whether WriteSQL creates the table before inserting rows.
+func CreateTable(b bool) WriteOption {
+	return func(c *writeOptions) {
+		c.createTable = b
+	}
+}
+
+// WithSQLTypes sets the SQL column types used by WriteSQL to create a table.
+// Series types not present on the map fall back to DefaultSQLTypes.
+func WithSQLTypes(types map[series.Type]string) WriteOption {
+	return func(c *writeOptions) {
+		c.sqlTypes = types
+	}
+}
+
+// WriteSQL inserts the rows of the DataFrame into the given table, mapping
+// each column to the table column with the same name. Rows are inserted in
+// batches with parameterized statements inside a single transaction, using
+// "?" as placeholder. NaN elements are inserted as NULL.
+//
+// If the CreateTable option is set, the table is created first, with the
+// column types given by WithSQLTypes or DefaultSQLTypes.
+func (df GotaDataFrame) WriteSQL(db *sql.DB, table string, options ...WriteOption) error {
+	if df.Err != nil {
+		return df.Err
+	}
+
+	// Set the default write options
+	cfg := writeOptions{
+		batchSize: 100,
+	}
+
+	// Set any custom write options
+	for _, option := range options {
+		option(&cfg)
+	}
+	if cfg.batchSize <= 0 {
+		return fmt.Errorf("write sql: batch size must be positive")
+	}
+
+	colnames := make([]string, df.ncols)
+	for i, colname := range df.Names() {
+		colnames[i] = quoteSQLIdentifier(colname)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("write sql: %v", err)
+	}
+	if cfg.createTable {
+		coldefs := make([]string, df.ncols)
+		for i, col := range df.columns {
+			t, ok := cfg.sqlTypes[col.Type()]
+			if !ok {
+				t, ok = DefaultSQLTypes[col.Type()]
+			}
+			if !ok {
+				tx.Rollback()
+				return fmt.Errorf("write sql: no SQL type for column %s", col.Name)
+			}
+			coldefs[i] = colnames[i] + " " + t
+		}
+		query := fmt.Sprintf("CREATE TABLE %s (%s)", quoteSQLIdentifier(table), strings.Join(coldefs, ", "))
+		if _, err := tx.Exec(query); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("write sql: %v", err)
+		}
+	}
+
+	placeholders := "(" + strings.TrimSuffix(strings.Repeat("?, ", df.ncols), ", ") + ")"
+	for start := 0; start < df.nrows; start += cfg.batchSize {
+		end := start + cfg.batchSize
+		if end > df.nrows {
+			end = df.nrows
+		}
+		values := make([]string, 0, end-start)
+		args := make([]interface{}, 0, (end-start)*df.ncols)
+		for i := start; i < end; i++ {
+			values = append(values, placeholders)
+			for _, col := range df.columns {
+				arg, err := sqlValue(col.Elem(i), col.Type())
+				if err != nil {
+					tx.Rollback()
+					return fmt.Errorf("write sql: %v", err)
+				}
+				args = append(args, arg)
+			}
+		}
+		query := fmt.Sprintf(
+			"INSERT INTO %s (%s) VALUES %s",
+			quoteSQLIdentifier(table),
+			strings.Join(colnames, ", "),
+			strings.Join(values, ", "),
+		)
+		if _, err := tx.Exec(query, args...); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("write sql: %v", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("write sql: %v", err)
+	}
+	return nil
+}
+
+// sqlValue returns the value of an Element as a SQL statement argument. NaN
+// elements are returned as nil, to be inserted as NULL.
+func sqlValue(e series.Element, t series.Type) (interface{}, error) {
+	if e.IsNA() {
+		return nil, nil
+	}
+	switch t {
+	case series.Int:
+		return e.Int()
+	case series.Float:
+		return e.Float(), nil
+	case series.Bool:
+		return e.Bool()
+	default:
+		return e.String(), nil
+	}
+}
+
+// quoteSQLIdentifier quotes a table or column name for its use in a SQL
+// statement.
+func quoteSQLIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
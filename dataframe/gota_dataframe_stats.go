@@ -0,0 +1,86 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/go-gota/gota/series"
+	"gonum.org/v1/gonum/stat"
+)
+
+// Statistical methods
+// ===================
+
+// Corr returns the Pearson correlation matrix of the numeric columns of the
+// DataFrame. The resulting DataFrame has a "column" label column with the names
+// of the numeric columns, followed by one Float column for each of them.
+// Non-numeric columns are skipped and NaN elements are excluded pairwise, so
+// every correlation uses the rows where both columns have values.
+func (df GotaDataFrame) Corr() DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	return df.pairwiseMatrix("corr", func(x, y []float64) float64 {
+		return stat.Correlation(x, y, nil)
+	})
+}
+
+// Cov returns the sample covariance matrix of the numeric columns of the
+// DataFrame, using n-1 degrees of freedom. The resulting DataFrame has the same
+// layout as the one returned by Corr, and NaN elements are excluded pairwise.
+func (df GotaDataFrame) Cov() DataFrame {
+	if df.Err != nil {
+		return df
+	}
+	return df.pairwiseMatrix("cov", func(x, y []float64) float64 {
+		return stat.Covariance(x, y, nil)
+	})
+}
+
+// pairwiseMatrix returns the square matrix resulting of applying f to every
+// pair of numeric columns of the DataFrame, excluding NaN elements pairwise.
+// Pairs with less than two complete observations are NaN.
+func (df GotaDataFrame) pairwiseMatrix(opname string, f func(x, y []float64) float64) DataFrame {
+	var names []string
+	var values [][]float64
+	var isNaN [][]bool
+	for _, col := range df.columns {
+		if col.Type() != series.Int && col.Type() != series.Float {
+			continue
+		}
+		names = append(names, col.Name)
+		values = append(values, col.Float())
+		isNaN = append(isNaN, col.IsNaN())
+	}
+	if len(names) == 0 {
+		return GotaDataFrame{Err: fmt.Errorf("%s: no numeric columns", opname)}
+	}
+
+	matrix := make([][]float64, len(names))
+	for i := range matrix {
+		matrix[i] = make([]float64, len(names))
+	}
+	for i := range names {
+		for j := i; j < len(names); j++ {
+			var x, y []float64
+			for k := 0; k < df.nrows; k++ {
+				if isNaN[i][k] || isNaN[j][k] {
+					continue
+				}
+				x = append(x, values[i][k])
+				y = append(y, values[j][k])
+			}
+			v := math.NaN()
+			if len(x) >= 2 {
+				v = f(x, y)
+			}
+			matrix[i][j], matrix[j][i] = v, v
+		}
+	}
+
+	columns := []series.Series1{series.New(names, series.String, "column")}
+	for i, name := range names {
+		columns = append(columns, series.New(matrix[i], series.Float, name))
+	}
+	return New(columns...)
+}
@@ -1,7 +1,9 @@
 package dataframe
 
 import (
+	"context"
 	"fmt"
+	"sort"
 
 	"github.com/go-gota/gota/series"
 )
@@ -14,75 +16,160 @@ type Groups struct {
 	Err         error
 }
 
-// Aggregation :Aggregate dataframe by aggregation type and aggregation column name
+// Aggregation :Aggregate dataframe by aggregation type and aggregation column name.
+// The resulting DataFrame has one row per group, sorted by group key, with the
+// grouping columns followed by one Float column per aggregation named
+// "<colname>_<type>".
 func (gps Groups) Aggregation(typs []AggregationType, colnames []string) DataFrame {
+	return gps.aggregateGroups(context.Background(), typs, colnames)
+}
+
+// AggregationCtx is like Aggregation, but stops the aggregation as soon as
+// possible if the given context is done, returning the error of the context.
+func (gps Groups) AggregationCtx(ctx context.Context, typs []AggregationType, colnames []string) (DataFrame, error) {
+	ret := gps.aggregateGroups(ctx, typs, colnames)
+	return ret, ret.Error()
+}
+
+func (gps Groups) aggregateGroups(ctx context.Context, typs []AggregationType, colnames []string) DataFrame {
+	if gps.Err != nil {
+		return GotaDataFrame{Err: gps.Err}
+	}
 	if gps.groups == nil {
 		return GotaDataFrame{Err: fmt.Errorf("Aggregation: input is nil")}
 	}
 	if len(typs) != len(colnames) {
 		return GotaDataFrame{Err: fmt.Errorf("Aggregation: len(typs) != len(colanmes)")}
 	}
-	dfMaps := make([]map[string]interface{}, 0)
-	for _, df := range gps.groups {
-		targetMap := df.Maps()[0]
-		curMap := make(map[string]interface{})
-		// add columns of  group by
-		for _, c := range gps.colnames {
-			if value, ok := targetMap[c]; ok {
-				curMap[c] = value
-			} else {
+
+	keys := gps.sortedKeys()
+	columns, err := gps.keyColumns(keys)
+	if err != nil {
+		return GotaDataFrame{Err: fmt.Errorf("Aggregation: %v", err)}
+	}
+	for i, c := range colnames {
+		values := make([]float64, len(keys))
+		for j, k := range keys {
+			if err := ctx.Err(); err != nil {
+				return GotaDataFrame{Err: err}
+			}
+			curSeries := gps.groups[k].Col(c)
+			if curSeries.Err != nil {
 				return GotaDataFrame{Err: fmt.Errorf("Aggregation: can't find column name: %s", c)}
 			}
+			value, err := aggregate(curSeries, typs[i])
+			if err != nil {
+				return GotaDataFrame{Err: fmt.Errorf("Aggregation: %v", err)}
+			}
+			values[j] = value
 		}
-		// Aggregation
-		for i, c := range colnames {
-			curSeries := df.Col(c)
-			var value float64
-			switch typs[i] {
-			case Aggregation_MAX:
-				value = curSeries.Max()
-			case Aggregation_MEAN:
-				value = curSeries.Mean()
-			case Aggregation_MEDIAN:
-				value = curSeries.Median()
-			case Aggregation_MIN:
-				value = curSeries.Min()
-			case Aggregation_STD:
-				value = curSeries.StdDev()
-			case Aggregation_SUM:
-				value = curSeries.Sum()
-			case Aggregation_COUNT:
-				value = float64(curSeries.Len())
-			default:
-				return GotaDataFrame{Err: fmt.Errorf("Aggregation: this method %s not found", typs[i])}
+		columns = append(columns, series.New(values, series.Float, fmt.Sprintf("%s_%s", c, typs[i])))
+	}
 
+	gps.aggregation = New(columns...)
+	return gps.aggregation
+}
+
+// GetGroups returns the grouped data frames created by GroupBy
+func (g Groups) GetGroups() map[string]DataFrame {
+	return g.groups
+}
+
+// sortedKeys returns the keys of the groups in ascending order.
+func (g Groups) sortedKeys() []string {
+	keys := make([]string, 0, len(g.groups))
+	for k := range g.groups {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// keyColumns returns the grouping columns with one element per group, in the
+// order given by keys. The columns keep their original types.
+func (g Groups) keyColumns(keys []string) ([]series.Series1, error) {
+	columns := make([]series.Series1, len(g.colnames))
+	for i, c := range g.colnames {
+		var t series.Type
+		elements := make([]interface{}, len(keys))
+		for j, k := range keys {
+			col := g.groups[k].Col(c)
+			if col.Err != nil {
+				return nil, fmt.Errorf("can't find column name: %s", c)
 			}
-			curMap[fmt.Sprintf("%s_%s", c, typs[i])] = value
+			t = col.Type()
+			elements[j] = col.Elem(0)
 		}
-		dfMaps = append(dfMaps, curMap)
+		columns[i] = series.New(elements, t, c)
+	}
+	return columns, nil
+}
 
+// aggregate reduces the given Series to a single value with the given
+// AggregationType.
+func aggregate(s series.Series1, typ AggregationType) (float64, error) {
+	switch typ {
+	case Aggregation_MAX:
+		return s.Max(), nil
+	case Aggregation_MEAN:
+		return s.Mean(), nil
+	case Aggregation_MEDIAN:
+		return s.Median(), nil
+	case Aggregation_MIN:
+		return s.Min(), nil
+	case Aggregation_STD:
+		return s.StdDev(), nil
+	case Aggregation_SUM:
+		return s.Sum(), nil
+	case Aggregation_COUNT:
+		return float64(s.Len()), nil
+	default:
+		return 0, fmt.Errorf("this method %s not found", typ)
 	}
+}
 
-	// Save column types
-	colTypes := map[string]series.Type{}
-	for k := range dfMaps[0] {
-		switch dfMaps[0][k].(type) {
-		case string:
-			colTypes[k] = series.String
-		case int, int16, int32, int64:
-			colTypes[k] = series.Int
-		case float32, float64:
-			colTypes[k] = series.Float
-		default:
-			continue
-		}
+// AggregationMulti aggregates each of the given columns by several aggregation
+// types at once. The output columns are named "<colname>_<type>" and appear
+// sorted by column name, and then in the order the aggregation types were
+// given, after the grouping columns.
+func (gps Groups) AggregationMulti(specs map[string][]AggregationType) DataFrame {
+	colnames := make([]string, 0, len(specs))
+	for c := range specs {
+		colnames = append(colnames, c)
 	}
+	sort.Strings(colnames)
 
-	gps.aggregation = LoadMaps(dfMaps, WithTypes(colTypes))
-	return gps.aggregation
+	var typs []AggregationType
+	var cols []string
+	for _, c := range colnames {
+		for _, typ := range specs[c] {
+			typs = append(typs, typ)
+			cols = append(cols, c)
+		}
+	}
+	return gps.Aggregation(typs, cols)
 }
 
-// GetGroups returns the grouped data frames created by GroupBy
-func (g Groups) GetGroups() map[string]DataFrame {
-	return g.groups
+// Count returns a DataFrame with one row per group, sorted by group key, with
+// the grouping columns and an Int column named "count" holding the number of
+// rows of each group.
+func (gps Groups) Count() DataFrame {
+	if gps.Err != nil {
+		return GotaDataFrame{Err: gps.Err}
+	}
+	if gps.groups == nil {
+		return GotaDataFrame{Err: fmt.Errorf("Count: input is nil")}
+	}
+
+	keys := gps.sortedKeys()
+	columns, err := gps.keyColumns(keys)
+	if err != nil {
+		return GotaDataFrame{Err: fmt.Errorf("Count: %v", err)}
+	}
+	counts := make([]int, len(keys))
+	for i, k := range keys {
+		counts[i], _ = gps.groups[k].Dims()
+	}
+	columns = append(columns, series.New(counts, series.Int, "count"))
+	return New(columns...)
 }
@@ -1,17 +1,75 @@
 package dataframe
 
+import (
+	"sort"
+	"strconv"
+
+	"github.com/go-gota/gota/series"
+)
+
 // Order is the ordering structure
 type Order struct {
 	Colname string
 	Reverse bool
+	Numeric bool // Compare the records of the column as numbers
 }
 
 // Sort return an ordering structure for regular column sorting sort.
 func Sort(colname string) Order {
-	return Order{colname, false}
+	return Order{colname, false, false}
 }
 
 // RevSort return an ordering structure for reverse column sorting.
 func RevSort(colname string) Order {
-	return Order{colname, true}
+	return Order{colname, true, false}
+}
+
+// NumSort return an ordering structure for numeric column sorting. It is
+// intended for String columns holding numeric text, so that "2" sorts before
+// "10".
+func NumSort(colname string) Order {
+	return Order{colname, false, true}
+}
+
+// RevNumSort return an ordering structure for reverse numeric column sorting.
+func RevNumSort(colname string) Order {
+	return Order{colname, true, true}
+}
+
+// numericOrder returns the indexes for sorting a Series by parsing its records
+// as floats. Records that can't be parsed are placed after the numeric ones in
+// lexicographic order, and NaN elements are pushed to the end by order of
+// appearance.
+func numericOrder(s series.Series1, reverse bool) []int {
+	records := s.Records()
+	isNaN := s.IsNaN()
+	values := make([]float64, len(records))
+	var numIdx, strIdx, nasIdx []int
+	for i, r := range records {
+		if isNaN[i] {
+			nasIdx = append(nasIdx, i)
+			continue
+		}
+		f, err := strconv.ParseFloat(r, 64)
+		if err != nil {
+			strIdx = append(strIdx, i)
+			continue
+		}
+		values[i] = f
+		numIdx = append(numIdx, i)
+	}
+	sort.SliceStable(numIdx, func(a, b int) bool {
+		if reverse {
+			return values[numIdx[a]] > values[numIdx[b]]
+		}
+		return values[numIdx[a]] < values[numIdx[b]]
+	})
+	sort.SliceStable(strIdx, func(a, b int) bool {
+		if reverse {
+			return records[strIdx[a]] > records[strIdx[b]]
+		}
+		return records[strIdx[a]] < records[strIdx[b]]
+	})
+	ret := append(numIdx, strIdx...)
+	return append(ret, nasIdx...)
 }
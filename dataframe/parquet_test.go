@@ -0,0 +1,95 @@
+package dataframe
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/go-gota/gota/series"
+	"github.com/parquet-go/parquet-go"
+)
+
+func TestReadParquet(t *testing.T) {
+	type row struct {
+		I *int64  `parquet:"i,optional"`
+		F float64 `parquet:"f"`
+		S *string `parquet:"s,optional"`
+		B bool    `parquet:"b"`
+	}
+	one, three := int64(1), int64(3)
+	a, c := "a", "c"
+
+	var buf bytes.Buffer
+	w := parquet.NewGenericWriter[row](&buf)
+	_, err := w.Write([]row{
+		{&one, 1.5, &a, true},
+		{nil, 2.5, nil, false},
+		{&three, 3.5, &c, true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	b := ReadParquet(&buf)
+	expDf := New(
+		series.New([]interface{}{1, nil, 3}, series.Int, "i"),
+		series.New([]float64{1.5, 2.5, 3.5}, series.Float, "f"),
+		series.New([]interface{}{"a", nil, "c"}, series.String, "s"),
+		series.New([]bool{true, false, true}, series.Bool, "b"),
+	)
+
+	if err := b.Error(); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	if nrows, ncols := b.Dims(); nrows != 3 || ncols != 4 {
+		t.Errorf("Expected dimensions 3x4, got %dx%d", nrows, ncols)
+	}
+	if !reflect.DeepEqual(expDf.Types(), b.Types()) {
+		t.Errorf("Different types:\nA:%v\nB:%v", expDf.Types(), b.Types())
+	}
+	if !reflect.DeepEqual(expDf.Names(), b.Names()) {
+		t.Errorf("Different colnames:\nA:%v\nB:%v", expDf.Names(), b.Names())
+	}
+	if !reflect.DeepEqual(expDf.Records(), b.Records()) {
+		t.Errorf("Different values:\nA:%v\nB:%v", expDf.Records(), b.Records())
+	}
+	if !b.Col("s").Elem(1).IsNA() {
+		t.Errorf("Expected null string to be loaded as NaN")
+	}
+
+	if err := ReadParquet(bytes.NewReader([]byte("not parquet"))).Error(); err == nil {
+		t.Errorf("Expected error due to invalid file")
+	}
+}
+
+func TestDataFrame_WriteParquet(t *testing.T) {
+	a := New(
+		series.New([]interface{}{"b", nil, "a"}, series.String, "name"),
+		series.New([]interface{}{1, 2, nil}, series.Int, "age"),
+		series.New([]interface{}{3.5, nil, 4.25}, series.Float, "score"),
+		series.New([]interface{}{true, false, nil}, series.Bool, "active"),
+	)
+	for _, c := range []Compression{CompressionSnappy, CompressionGzip, CompressionNone} {
+		var buf bytes.Buffer
+		if err := a.WriteParquet(&buf, WithCompression(c)); err != nil {
+			t.Fatalf("Compression: %d\nError:%v", c, err)
+		}
+		b := ReadParquet(&buf)
+
+		if err := b.Error(); err != nil {
+			t.Fatalf("Compression: %d\nError:%v", c, err)
+		}
+		if !reflect.DeepEqual(a.Types(), b.Types()) {
+			t.Errorf("Compression: %d\nDifferent types:\nA:%v\nB:%v", c, a.Types(), b.Types())
+		}
+		if !reflect.DeepEqual(a.Names(), b.Names()) {
+			t.Errorf("Compression: %d\nDifferent colnames:\nA:%v\nB:%v", c, a.Names(), b.Names())
+		}
+		if !reflect.DeepEqual(a.Records(), b.Records()) {
+			t.Errorf("Compression: %d\nDifferent values:\nA:%v\nB:%v", c, a.Records(), b.Records())
+		}
+	}
+}
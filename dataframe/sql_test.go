@@ -0,0 +1,213 @@
+package dataframe
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/go-gota/gota/series"
+)
+
+// fakeDB is an in-memory database used for testing the SQL reader and writer
+// without depending on a real driver. Queries return the stored rows and
+// executed statements are recorded.
+type fakeDB struct {
+	mu      sync.Mutex
+	columns []string
+	rows    [][]driver.Value
+	execs   []fakeExec
+}
+
+type fakeExec struct {
+	query string
+	args  []driver.Value
+}
+
+var (
+	fakeDBsMu sync.Mutex
+	fakeDBs   = map[string]*fakeDB{}
+)
+
+func init() {
+	sql.Register("gotafake", fakeDriver{})
+}
+
+// openFakeDB registers a fakeDB with the given name and opens it.
+func openFakeDB(t *testing.T, name string, fdb *fakeDB) *sql.DB {
+	fakeDBsMu.Lock()
+	fakeDBs[name] = fdb
+	fakeDBsMu.Unlock()
+	db, err := sql.Open("gotafake", name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(name string) (driver.Conn, error) {
+	fakeDBsMu.Lock()
+	defer fakeDBsMu.Unlock()
+	fdb, ok := fakeDBs[name]
+	if !ok {
+		return nil, fmt.Errorf("fake database %s not found", name)
+	}
+	return &fakeConn{fdb}, nil
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{c.db, query}, nil
+}
+
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return fakeTx{}, nil }
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeStmt struct {
+	db    *fakeDB
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.db.mu.Lock()
+	defer s.db.mu.Unlock()
+	s.db.execs = append(s.db.execs, fakeExec{s.query, args})
+	return driver.RowsAffected(0), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &fakeRows{db: s.db}, nil
+}
+
+type fakeRows struct {
+	db  *fakeDB
+	pos int
+}
+
+func (r *fakeRows) Columns() []string { return r.db.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.db.rows) {
+		return io.EOF
+	}
+	copy(dest, r.db.rows[r.pos])
+	r.pos++
+	return nil
+}
+
+func TestReadSQL(t *testing.T) {
+	db := openFakeDB(t, "readsql", &fakeDB{
+		columns: []string{"name", "age", "score", "active"},
+		rows: [][]driver.Value{
+			{[]byte("alice"), int64(30), 1.5, true},
+			{"bob", nil, 2.25, false},
+			{nil, int64(25), nil, true},
+			{"NA", int64(41), 3.0, nil},
+		},
+	})
+	defer db.Close()
+
+	rows, err := db.Query("SELECT name, age, score, active FROM people")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	b := ReadSQL(rows)
+
+	expDf := New(
+		series.New([]interface{}{"alice", "bob", nil, "NA"}, series.String, "name"),
+		series.New([]interface{}{30, nil, 25, 41}, series.Int, "age"),
+		series.New([]interface{}{1.5, 2.25, nil, 3.0}, series.Float, "score"),
+		series.New([]interface{}{true, false, true, nil}, series.Bool, "active"),
+	)
+
+	if err := b.Error(); err != nil {
+		t.Errorf("Error:%v", err)
+	}
+	if !reflect.DeepEqual(expDf.Types(), b.Types()) {
+		t.Errorf("Different types:\nA:%v\nB:%v", expDf.Types(), b.Types())
+	}
+	if !reflect.DeepEqual(expDf.Names(), b.Names()) {
+		t.Errorf("Different colnames:\nA:%v\nB:%v", expDf.Names(), b.Names())
+	}
+	if !reflect.DeepEqual(expDf.Records(), b.Records()) {
+		t.Errorf("Different values:\nA:%v\nB:%v", expDf.Records(), b.Records())
+	}
+	for i, isNaN := range []bool{false, true, false, false} {
+		if b.Col("age").Elem(i).IsNA() != isNaN {
+			t.Errorf("Index:%d\nExpected age NaN to be %v", i, isNaN)
+		}
+	}
+
+	if err := ReadSQL(nil).Error(); err == nil {
+		t.Errorf("Expected error due to nil rows")
+	}
+}
+
+func TestDataFrame_WriteSQL(t *testing.T) {
+	a := New(
+		series.New([]interface{}{"a", "b", nil}, series.String, "name"),
+		series.New([]interface{}{1, nil, 3}, series.Int, "age"),
+		series.New([]interface{}{1.5, 2.5, 3.5}, series.Float, "score"),
+	)
+	fdb := &fakeDB{}
+	db := openFakeDB(t, "writesql", fdb)
+	defer db.Close()
+
+	err := a.WriteSQL(
+		db, "people",
+		WithBatchSize(2),
+		CreateTable(true),
+		WithSQLTypes(map[series.Type]string{series.Float: "DOUBLE PRECISION"}),
+	)
+	if err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+
+	expected := []fakeExec{
+		{
+			`CREATE TABLE "people" ("name" TEXT, "age" INTEGER, "score" DOUBLE PRECISION)`,
+			[]driver.Value{},
+		},
+		{
+			`INSERT INTO "people" ("name", "age", "score") VALUES (?, ?, ?), (?, ?, ?)`,
+			[]driver.Value{"a", int64(1), 1.5, "b", nil, 2.5},
+		},
+		{
+			`INSERT INTO "people" ("name", "age", "score") VALUES (?, ?, ?)`,
+			[]driver.Value{nil, int64(3), 3.5},
+		},
+	}
+	if !reflect.DeepEqual(expected, fdb.execs) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, fdb.execs)
+	}
+
+	rows := 0
+	for _, exec := range fdb.execs[1:] {
+		rows += len(exec.args) / a.NCol()
+	}
+	if rows != a.NRow() {
+		t.Errorf("Expected %d inserted rows, got %d", a.NRow(), rows)
+	}
+
+	if err := a.WriteSQL(db, "people", WithBatchSize(0)); err == nil {
+		t.Errorf("Expected error due to non positive batch size")
+	}
+}
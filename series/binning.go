@@ -0,0 +1,126 @@
+package series
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// Cut assigns each element of the Series to one of the intervals defined by the
+// given bin edges, which must be strictly increasing. Intervals are closed on
+// the right, (bins[i], bins[i+1]], except for the first one, which also
+// includes its left edge. Each element is replaced by the label of its
+// interval; if labels is nil, the intervals themselves are used as labels.
+// Elements outside of the outermost edges and NaN elements map to NaN. Cut is
+// only defined for numeric Series.
+func (s *GotaSeries[T]) Cut(bins []float64, labels []string) Series[string] {
+	if s.Err != nil {
+		return newErrorSeries[string](s.Name, s.Err)
+	}
+	if !isNumeric[T]() {
+		return newErrorSeries[string](s.Name, fmt.Errorf("cut: series is not numeric"))
+	}
+	if len(bins) < 2 {
+		return newErrorSeries[string](s.Name, fmt.Errorf("cut: at least two bin edges are required"))
+	}
+	for i := 1; i < len(bins); i++ {
+		if !(bins[i] > bins[i-1]) {
+			return newErrorSeries[string](s.Name, fmt.Errorf("cut: bin edges must be strictly increasing"))
+		}
+	}
+	if labels == nil {
+		labels = intervalLabels(bins)
+	}
+	if len(labels) != len(bins)-1 {
+		return newErrorSeries[string](s.Name, fmt.Errorf("cut: number of labels must be one less than the number of bin edges"))
+	}
+
+	elements := make([]Element[string], s.Len())
+	for i := range elements {
+		v := elementFloat(s.elements.Elem(i))
+		j := sort.SearchFloat64s(bins, v)
+		switch {
+		case math.IsNaN(v), j == len(bins), j == 0 && v < bins[0]:
+			elements[i] = NewNAElement[string]()
+		case j == 0:
+			elements[i] = NewElement(labels[0])
+		default:
+			elements[i] = NewElement(labels[j-1])
+		}
+	}
+
+	ret := GotaSeries[string]{
+		Name:     s.Name,
+		elements: &ElementsArray[string]{len(elements), elements},
+	}
+	return &ret
+}
+
+// QCut splits the elements of the Series into q buckets with the same number of
+// elements, using the quantiles of the Series as bin edges for Cut. The
+// quantiles are linearly interpolated between the values of the Series. It
+// fails if repeated values make two of the edges equal.
+func (s *GotaSeries[T]) QCut(q int) Series[string] {
+	if s.Err != nil {
+		return newErrorSeries[string](s.Name, s.Err)
+	}
+	if !isNumeric[T]() {
+		return newErrorSeries[string](s.Name, fmt.Errorf("qcut: series is not numeric"))
+	}
+	if q < 1 {
+		return newErrorSeries[string](s.Name, fmt.Errorf("qcut: number of quantiles must be positive"))
+	}
+
+	var values []float64
+	for i := 0; i < s.Len(); i++ {
+		if v := elementFloat(s.elements.Elem(i)); !math.IsNaN(v) {
+			values = append(values, v)
+		}
+	}
+	if len(values) == 0 {
+		return newErrorSeries[string](s.Name, fmt.Errorf("qcut: series has no values"))
+	}
+	sort.Float64s(values)
+
+	bins := make([]float64, q+1)
+	for i := range bins {
+		bins[i] = linearQuantile(float64(i)/float64(q), values)
+	}
+	for i := 1; i < len(bins); i++ {
+		if !(bins[i] > bins[i-1]) {
+			return newErrorSeries[string](s.Name, fmt.Errorf("qcut: bin edges are not unique"))
+		}
+	}
+	return s.Cut(bins, nil)
+}
+
+// linearQuantile returns the p quantile of the given sorted values, linearly
+// interpolating between the two closest ones as pandas does. stat.Quantile,
+// both with stat.Empirical and stat.LinInterp, returns the first value for
+// every p up to 1/n, which repeats the first edges whenever q is close to n.
+func linearQuantile(p float64, sorted []float64) float64 {
+	h := p * float64(len(sorted)-1)
+	i := int(math.Floor(h))
+	if i >= len(sorted)-1 {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[i] + (h-float64(i))*(sorted[i+1]-sorted[i])
+}
+
+// intervalLabels returns the labels of the intervals defined by the given bin
+// edges, as used by Cut.
+func intervalLabels(bins []float64) []string {
+	format := func(f float64) string {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	labels := make([]string, len(bins)-1)
+	for i := range labels {
+		open := "("
+		if i == 0 {
+			open = "["
+		}
+		labels[i] = fmt.Sprintf("%s%s, %s]", open, format(bins[i]), format(bins[i+1]))
+	}
+	return labels
+}
@@ -0,0 +1,188 @@
+package series
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Categorical is a Series of strings restricted to a fixed set of levels. Its
+// elements are stored as integer codes referencing the levels, which reduces
+// the memory used by repetitive values, and are ordered by the position of
+// their level instead of lexicographically, so that ordinal data like "low",
+// "medium" and "high" can be compared and sorted correctly.
+type Categorical struct {
+	Name   string
+	levels []string
+	codes  []int // -1 for NaN elements
+	Err    error
+}
+
+// NewCategorical creates a Categorical Series with the given values and levels,
+// where the order of the levels defines the order of the elements. Values that
+// are not one of the levels are stored as NaN.
+func NewCategorical(values []string, levels []string) *Categorical {
+	lookup := make(map[string]int, len(levels))
+	for i, l := range levels {
+		if _, ok := lookup[l]; ok {
+			return &Categorical{Err: fmt.Errorf("categorical error: duplicated level %q", l)}
+		}
+		lookup[l] = i
+	}
+	codes := make([]int, len(values))
+	for i, v := range values {
+		code, ok := lookup[v]
+		if !ok {
+			code = -1
+		}
+		codes[i] = code
+	}
+	return &Categorical{
+		levels: append([]string(nil), levels...),
+		codes:  codes,
+	}
+}
+
+// Error returns the error of the Categorical Series, if any.
+func (c *Categorical) Error() error {
+	return c.Err
+}
+
+// Len returns the length of the Categorical Series.
+func (c *Categorical) Len() int {
+	return len(c.codes)
+}
+
+// Levels returns a copy of the levels of the Categorical Series, in order.
+func (c *Categorical) Levels() []string {
+	return append([]string(nil), c.levels...)
+}
+
+// Codes returns a copy of the integer codes of the elements, which are the
+// positions of their levels. NaN elements have code -1.
+func (c *Categorical) Codes() []int {
+	return append([]int(nil), c.codes...)
+}
+
+// Val returns the level of the element at the given index, or "NaN" if the
+// element is NaN.
+func (c *Categorical) Val(i int) string {
+	if c.codes[i] < 0 {
+		return "NaN"
+	}
+	return c.levels[c.codes[i]]
+}
+
+// IsNaN returns an array that identifies which of the elements are NaN.
+func (c *Categorical) IsNaN() []bool {
+	ret := make([]bool, len(c.codes))
+	for i, code := range c.codes {
+		ret[i] = code < 0
+	}
+	return ret
+}
+
+// Records returns the elements of the Categorical Series as a []string.
+func (c *Categorical) Records() []string {
+	ret := make([]string, len(c.codes))
+	for i := range c.codes {
+		ret[i] = c.Val(i)
+	}
+	return ret
+}
+
+// Strings converts the Categorical Series to a String Series.
+func (c *Categorical) Strings() Series[string] {
+	if c.Err != nil {
+		return newErrorSeries[string](c.Name, c.Err)
+	}
+	elements := make([]Element[string], len(c.codes))
+	for i, code := range c.codes {
+		if code < 0 {
+			elements[i] = NewNAElement[string]()
+			continue
+		}
+		elements[i] = NewElement(c.levels[code])
+	}
+	ret := GotaSeries[string]{
+		Name:     c.Name,
+		elements: &ElementsArray[string]{len(elements), elements},
+	}
+	return &ret
+}
+
+// Compare compares the elements of the Categorical Series with the given level
+// according to the order of the levels. NaN elements never match, and an error
+// is returned if the comparando is not one of the levels or if the comparator
+// is not supported.
+func (c *Categorical) Compare(comparator Comparator, comparando string) ([]bool, error) {
+	if c.Err != nil {
+		return nil, c.Err
+	}
+	other := -1
+	for i, l := range c.levels {
+		if l == comparando {
+			other = i
+			break
+		}
+	}
+	if other < 0 {
+		return nil, fmt.Errorf("compare error: unknown level %q", comparando)
+	}
+	ret := make([]bool, len(c.codes))
+	for i, code := range c.codes {
+		if code < 0 {
+			continue
+		}
+		switch comparator {
+		case Eq:
+			ret[i] = code == other
+		case Neq:
+			ret[i] = code != other
+		case Greater:
+			ret[i] = code > other
+		case GreaterEq:
+			ret[i] = code >= other
+		case Less:
+			ret[i] = code < other
+		case LessEq:
+			ret[i] = code <= other
+		default:
+			return nil, fmt.Errorf("unknown comparator: %v", comparator)
+		}
+	}
+	return ret, nil
+}
+
+// Order returns the indexes for sorting the Categorical Series by the order of
+// its levels. NaN elements are pushed to the end regardless of the order.
+func (c *Categorical) Order(reverse bool) []int {
+	var ret, nasIdx []int
+	for i, code := range c.codes {
+		if code < 0 {
+			nasIdx = append(nasIdx, i)
+			continue
+		}
+		ret = append(ret, i)
+	}
+	sort.SliceStable(ret, func(a, b int) bool {
+		if reverse {
+			return c.codes[ret[a]] > c.codes[ret[b]]
+		}
+		return c.codes[ret[a]] < c.codes[ret[b]]
+	})
+	return append(ret, nasIdx...)
+}
+
+// Groups returns the indexes of the elements belonging to each of the levels
+// present in the Categorical Series. NaN elements are not grouped.
+func (c *Categorical) Groups() map[string][]int {
+	groups := make(map[string][]int)
+	for i, code := range c.codes {
+		if code < 0 {
+			continue
+		}
+		l := c.levels[code]
+		groups[l] = append(groups[l], i)
+	}
+	return groups
+}
@@ -0,0 +1,81 @@
+package series
+
+import (
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// isNumeric reports whether the underlying type of T is a numeric type.
+func isNumeric[T SeriesType]() bool {
+	var zero T
+	return reflect.TypeOf(zero).Kind() != reflect.String
+}
+
+// toFloat converts a value of any SeriesType to float64. Strings that can't be
+// parsed as a number are converted to NaN.
+func toFloat[T SeriesType](v T) float64 {
+	switch val := any(v).(type) {
+	case int:
+		return float64(val)
+	case float64:
+		return val
+	case string:
+		f, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return math.NaN()
+		}
+		return f
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		return rv.Float()
+	case reflect.String:
+		f, err := strconv.ParseFloat(rv.String(), 64)
+		if err != nil {
+			return math.NaN()
+		}
+		return f
+	}
+	return math.NaN()
+}
+
+// fromFloat converts a float64 to the given SeriesType. Integer types are
+// truncated towards zero.
+func fromFloat[T SeriesType](f float64) T {
+	var zero T
+	switch any(zero).(type) {
+	case int:
+		return any(int(f)).(T)
+	case float64:
+		return any(f).(T)
+	case string:
+		return any(strconv.FormatFloat(f, 'f', -1, 64)).(T)
+	}
+	rv := reflect.New(reflect.TypeOf(zero)).Elem()
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		rv.SetInt(int64(f))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		rv.SetUint(uint64(f))
+	case reflect.Float32, reflect.Float64:
+		rv.SetFloat(f)
+	case reflect.String:
+		rv.SetString(strconv.FormatFloat(f, 'f', -1, 64))
+	}
+	return rv.Interface().(T)
+}
+
+// elementFloat returns the float64 representation of an Element, or NaN if the
+// Element is NaN.
+func elementFloat[T SeriesType](e Element[T]) float64 {
+	if e.IsNA() {
+		return math.NaN()
+	}
+	return toFloat(e.Val())
+}
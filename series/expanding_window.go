@@ -0,0 +1,105 @@
+package series
+
+import "math"
+
+// ExpandingSeries is used for expanding window calculations. Every calculation
+// returns a Series of the same length as the original one, where each element
+// is computed over all the elements up to and including it. NaN elements are
+// skipped, and positions without any previous non NaN element result in NaN.
+type ExpandingSeries interface {
+	Mean() Series[float64]
+	Sum() Series[float64]
+	Max() Series[float64]
+	Min() Series[float64]
+	StdDev() Series[float64]
+}
+
+// ExpandingWindow implements ExpandingSeries for a Series of type T.
+type ExpandingWindow[T SeriesType] struct {
+	series Series[T]
+	err    error
+}
+
+// Expanding creates new ExpandingWindow
+func (s *GotaSeries[T]) Expanding() ExpandingSeries {
+	return ExpandingWindow[T]{
+		series: s,
+		err:    s.Err,
+	}
+}
+
+// expandingState holds the running statistics of the elements seen so far.
+// The mean and the sum of squared deviations are updated with Welford's
+// algorithm.
+type expandingState struct {
+	n        int
+	sum      float64
+	mean, m2 float64
+	min, max float64
+}
+
+// Mean returns the expanding mean.
+func (w ExpandingWindow[T]) Mean() Series[float64] {
+	return w.apply("Mean", func(st *expandingState) float64 {
+		return st.mean
+	})
+}
+
+// Sum returns the expanding sum.
+func (w ExpandingWindow[T]) Sum() Series[float64] {
+	return w.apply("Sum", func(st *expandingState) float64 {
+		return st.sum
+	})
+}
+
+// Max returns the expanding maximum.
+func (w ExpandingWindow[T]) Max() Series[float64] {
+	return w.apply("Max", func(st *expandingState) float64 {
+		return st.max
+	})
+}
+
+// Min returns the expanding minimum.
+func (w ExpandingWindow[T]) Min() Series[float64] {
+	return w.apply("Min", func(st *expandingState) float64 {
+		return st.min
+	})
+}
+
+// StdDev returns the expanding sample standard deviation, which is NaN until
+// there are at least two non NaN elements.
+func (w ExpandingWindow[T]) StdDev() Series[float64] {
+	return w.apply("StdDev", func(st *expandingState) float64 {
+		if st.n < 2 {
+			return math.NaN()
+		}
+		return math.Sqrt(st.m2 / float64(st.n-1))
+	})
+}
+
+// apply computes f over the running statistics after every element.
+func (w ExpandingWindow[T]) apply(name string, f func(st *expandingState) float64) Series[float64] {
+	if w.err != nil {
+		return newErrorSeries[float64](name, w.err)
+	}
+
+	st := expandingState{min: math.Inf(1), max: math.Inf(-1)}
+	ret := make([]float64, w.series.Len())
+	for i := range ret {
+		v := elementFloat(w.series.Elem(i))
+		if !math.IsNaN(v) {
+			st.n++
+			st.sum += v
+			delta := v - st.mean
+			st.mean += delta / float64(st.n)
+			st.m2 += delta * (v - st.mean)
+			st.min = math.Min(st.min, v)
+			st.max = math.Max(st.max, v)
+		}
+		ret[i] = math.NaN()
+		if st.n > 0 {
+			ret[i] = f(&st)
+		}
+	}
+	return NewSeries(name, ret...)
+}
@@ -0,0 +1,49 @@
+package series
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestSeries_Expanding(t *testing.T) {
+	nan := math.NaN()
+	s := Floats(nan, 2, 4, nan, 6, 0)
+	tests := []struct {
+		received Series[float64]
+		expected []float64
+	}{
+		{s.Expanding().Sum(), []float64{nan, 2, 6, 6, 12, 12}},
+		{s.Expanding().Mean(), []float64{nan, 2, 3, 3, 4, 3}},
+		{s.Expanding().Max(), []float64{nan, 2, 4, 4, 6, 6}},
+		{s.Expanding().Min(), []float64{nan, 2, 2, 2, 2, 0}},
+		{s.Expanding().StdDev(), []float64{nan, nan, math.Sqrt(2), math.Sqrt(2), 2, math.Sqrt(20.0 / 3)}},
+	}
+	for testnum, test := range tests {
+		checkRolling(t, testnum, test.expected, test.received)
+	}
+}
+
+func TestSeries_ExpandingLast(t *testing.T) {
+	tests := []Series[float64]{
+		Floats(1.5, 3.2, -4.1, 8.8, 0.3),
+		Ints(3, 1, 4, 1, 5, 9, 2, 6).Expanding().Sum(),
+	}
+	for testnum, s := range tests {
+		last := s.Len() - 1
+		if mean := s.Expanding().Mean().Val(last); !compareFloats(s.Mean(), mean, 6) {
+			t.Errorf("Test:%v\nExpected mean %v, received %v", testnum, s.Mean(), mean)
+		}
+		if std := s.Expanding().StdDev().Val(last); !compareFloats(s.StdDev(), std, 6) {
+			t.Errorf("Test:%v\nExpected standard deviation %v, received %v", testnum, s.StdDev(), std)
+		}
+		if sum := s.Expanding().Sum().Val(last); !compareFloats(s.Sum(), sum, 6) {
+			t.Errorf("Test:%v\nExpected sum %v, received %v", testnum, s.Sum(), sum)
+		}
+	}
+
+	failed := &GotaSeries[int]{Name: "F", elements: NewElements[int](), Err: fmt.Errorf("failed")}
+	if err := failed.Expanding().Mean().Error(); err == nil {
+		t.Errorf("Expected error for series with errors")
+	}
+}
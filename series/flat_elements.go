@@ -0,0 +1,154 @@
+package series
+
+import "slices"
+
+// FlatElements stores the Elements of numeric Series in a contiguous slice of
+// values, with the NaN elements marked on a bitset. Compared to ElementsArray
+// it avoids an allocation per element and keeps the values close in memory,
+// which speeds up operations that scan the whole Series.
+type FlatElements[T SeriesType] struct {
+	values []T
+	nan    []uint64
+
+	// The Elements returned by Elem, kept along the values so that accessing
+	// them doesn't allocate.
+	views []flatElement[T]
+}
+
+// NewFlatElements returns FlatElements holding the given values. Floating point
+// NaN values result in NaN elements.
+func NewFlatElements[T SeriesType](values ...T) *FlatElements[T] {
+	fe := &FlatElements[T]{
+		values: make([]T, len(values)),
+		nan:    make([]uint64, (len(values)+63)/64),
+	}
+	copy(fe.values, values)
+	for i, v := range values {
+		if v != v {
+			fe.setNA(i, true)
+		}
+	}
+	fe.growViews()
+	return fe
+}
+
+func (fe *FlatElements[T]) Elem(i int) Element[T] {
+	return &fe.views[i]
+}
+
+func (fe *FlatElements[T]) Len() int {
+	return len(fe.values)
+}
+
+func (fe *FlatElements[T]) AppendElements(other Elements[T]) {
+	if o, ok := other.(*FlatElements[T]); ok {
+		n := len(fe.values)
+		fe.values = append(fe.values, o.values...)
+		fe.growNA()
+		for i := range o.values {
+			if o.isNA(i) {
+				fe.setNA(n+i, true)
+			}
+		}
+		fe.growViews()
+		return
+	}
+	for i := 0; i < other.Len(); i++ {
+		e := other.Elem(i)
+		fe.values = append(fe.values, e.Val())
+		fe.growNA()
+		if e.IsNA() {
+			fe.setNA(len(fe.values)-1, true)
+		}
+	}
+	fe.growViews()
+}
+
+// Grow increases the capacity of the FlatElements to hold at least n more
+// elements without reallocating.
+func (fe *FlatElements[T]) Grow(n int) {
+	fe.values = slices.Grow(fe.values, n)
+	fe.nan = slices.Grow(fe.nan, max(0, (len(fe.values)+n+63)/64-len(fe.nan)))
+	fe.views = slices.Grow(fe.views, n)
+}
+
+// Values returns the elements as a slice of Element. The Elements returned are
+// views over the underlying values, so setting them modifies the FlatElements.
+func (fe *FlatElements[T]) Values() []Element[T] {
+	ret := make([]Element[T], len(fe.values))
+	for i := range ret {
+		ret[i] = &fe.views[i]
+	}
+	return ret
+}
+
+func (fe *FlatElements[T]) isNA(i int) bool {
+	return fe.nan[i/64]&(1<<uint(i%64)) != 0
+}
+
+func (fe *FlatElements[T]) setNA(i int, na bool) {
+	if na {
+		fe.nan[i/64] |= 1 << uint(i%64)
+	} else {
+		fe.nan[i/64] &^= 1 << uint(i%64)
+	}
+}
+
+func (fe *FlatElements[T]) growNA() {
+	for len(fe.nan)*64 < len(fe.values) {
+		fe.nan = append(fe.nan, 0)
+	}
+}
+
+// growViews adds the Elements of the values appended since the last call.
+// Elements returned before the views are reallocated remain valid, since they
+// only refer to the FlatElements and their position.
+func (fe *FlatElements[T]) growViews() {
+	for i := len(fe.views); i < len(fe.values); i++ {
+		fe.views = append(fe.views, flatElement[T]{fe, i})
+	}
+}
+
+// flatElement is the Element at a given position of FlatElements.
+type flatElement[T SeriesType] struct {
+	fe *FlatElements[T]
+	i  int
+}
+
+func (e *flatElement[T]) Set(item T) {
+	e.fe.values[e.i] = item
+	e.fe.setNA(e.i, item != item)
+}
+
+func (e *flatElement[T]) Eq(other Element[T]) bool {
+	return e.IsNA() == other.IsNA() && e.Val() == other.Val()
+}
+func (e *flatElement[T]) Neq(other Element[T]) bool {
+	return e.IsNA() != other.IsNA() || e.Val() != other.Val()
+}
+
+func (e *flatElement[T]) Less(other Element[T]) bool {
+	return e.Val() < other.Val()
+}
+func (e *flatElement[T]) LessEq(other Element[T]) bool {
+	return e.Val() <= other.Val()
+}
+func (e *flatElement[T]) Greater(other Element[T]) bool {
+	return e.Val() > other.Val()
+}
+func (e *flatElement[T]) GreaterEq(other Element[T]) bool {
+	return e.Val() >= other.Val()
+}
+
+// Copy returns a copy of the element that doesn't share memory with the
+// FlatElements.
+func (e *flatElement[T]) Copy() Element[T] {
+	return &ElementValue[T]{e.Val(), e.IsNA()}
+}
+func (e *flatElement[T]) Val() T {
+	return e.fe.values[e.i]
+}
+
+func (e *flatElement[T]) IsNA() bool {
+	return e.fe.isNA(e.i)
+}
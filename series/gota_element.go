@@ -28,8 +28,11 @@ type ElementValue[T SeriesType] struct {
 	nan   bool
 }
 
+// Set sets the value of the Element. As in NewElement, the Element is NaN only
+// if the value is a floating point NaN.
 func (ev *ElementValue[T]) Set(item T) {
 	ev.value = item
+	ev.nan = item != item
 }
 
 func (ev *ElementValue[T]) Eq(other Element[T]) bool {
@@ -65,8 +68,16 @@ func (ev *ElementValue[T]) IsNA() bool {
 	return ev.nan
 }
 
+// NewElement returns an Element holding the given value. A floating point NaN
+// value, the only one not equal to itself, results in a NaN Element.
 func NewElement[T SeriesType](t T) Element[T] {
-	return &ElementValue[T]{t, false}
+	return &ElementValue[T]{t, t != t}
+}
+
+// NewNAElement returns a NaN Element of the given type.
+func NewNAElement[T SeriesType]() Element[T] {
+	var zero T
+	return &ElementValue[T]{zero, true}
 }
 
 type BoolElement interface {
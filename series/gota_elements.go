@@ -1,5 +1,7 @@
 package series
 
+import "slices"
+
 // Elements is the interface that represents the array of elements contained on
 // a Series.
 type Elements[T SeriesType] interface {
@@ -29,11 +31,22 @@ func (ea *ElementsArray[T]) AppendElements(other Elements[T]) {
 	ea.len += other_len
 }
 
+// Grow increases the capacity of the ElementsArray to hold at least n more
+// elements without reallocating.
+func (ea *ElementsArray[T]) Grow(n int) {
+	ea.elements = slices.Grow(ea.elements, n)
+}
+
 func (ea *ElementsArray[T]) Values() []Element[T] {
 	return ea.elements
 }
 
+// NewElements returns the Elements holding the given values. Numeric values are
+// stored as FlatElements and the rest as an ElementsArray.
 func NewElements[T SeriesType](values ...T) Elements[T] {
+	if isNumeric[T]() {
+		return NewFlatElements(values...)
+	}
 	length := len(values)
 	ea := make([]Element[T], length)
 	for i, v := range values {
@@ -42,6 +55,26 @@ func NewElements[T SeriesType](values ...T) Elements[T] {
 	return &ElementsArray[T]{length, ea}
 }
 
+// elementsOf stores the given Elements in the same way as NewElements, as
+// FlatElements for numeric types and as an ElementsArray otherwise.
+func elementsOf[T SeriesType](elements []Element[T]) Elements[T] {
+	if !isNumeric[T]() {
+		return &ElementsArray[T]{len(elements), elements}
+	}
+	fe := &FlatElements[T]{
+		values: make([]T, len(elements)),
+		nan:    make([]uint64, (len(elements)+63)/64),
+	}
+	for i, e := range elements {
+		fe.values[i] = e.Val()
+		if e.IsNA() {
+			fe.setNA(i, true)
+		}
+	}
+	fe.growViews()
+	return fe
+}
+
 // For BoolElements, need adjacent properties
 
 type MapFunction[T SeriesType] func(Element[T]) Element[T]
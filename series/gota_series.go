@@ -3,7 +3,9 @@ package series
 import (
 	"fmt"
 	"math"
+	"math/rand"
 	"sort"
+	"strconv"
 	"strings"
 
 	"gonum.org/v1/gonum/stat"
@@ -25,6 +27,17 @@ func NewSeries[T SeriesType](name string, values ...T) Series[T] {
 	return &ret
 }
 
+// newErrorSeries returns an empty Series with the given name and error.
+func newErrorSeries[T SeriesType](name string, err error) Series[T] {
+	ret := GotaSeries[T]{
+		Name:     name,
+		elements: NewElements[T](),
+		Err:      err,
+	}
+
+	return &ret
+}
+
 // Empty returns an empty Series of the same type
 func (s *GotaSeries[T]) Empty() Series[T] {
 	return NewSeries(s.Name, []T{}...)
@@ -44,20 +57,50 @@ func (s *GotaSeries[T]) Append(values ...T) {
 	s.elements.AppendElements(NewElements(values...))
 }
 
-// Concat concatenates two series together. It will return a new Series with the
-// combined elements of both Series.
+// AppendNA adds a NaN element of the Series type to the end of the Series.
+// As with Append, the Series is modified in place.
+func (s *GotaSeries[T]) AppendNA() {
+	if err := s.Err; err != nil {
+		return
+	}
+
+	s.elements.AppendElements(&ElementsArray[T]{1, []Element[T]{NewNAElement[T]()}})
+}
+
+// Grow preallocates space for appending at least n more elements to the Series,
+// avoiding repeated reallocations when the final size is known in advance.
+func (s *GotaSeries[T]) Grow(n int) {
+	if n <= 0 {
+		return
+	}
+	if g, ok := s.elements.(interface{ Grow(int) }); ok {
+		g.Grow(n)
+	}
+}
+
+// Concat concatenates two series together. It will return a new Series with
+// copies of the combined elements of both Series, so later modifications of
+// either of them don't affect the result.
 func (s *GotaSeries[T]) Concat(x Series[T]) Series[T] {
 	if err := s.Err; err != nil {
 		return s
 	}
 	if err := x.Error(); err != nil {
-		s.Err = fmt.Errorf("concat error: argument has errors: %v", err)
-		return s
+		return newErrorSeries[T](s.Name, fmt.Errorf("concat error: argument has errors: %v", err))
 	}
 
-	y := s.Copy()
-	y.Values().AppendElements(x.Values())
-	return y
+	elements := make([]Element[T], 0, s.Len()+x.Len())
+	for i := 0; i < s.Len(); i++ {
+		elements = append(elements, s.elements.Elem(i).Copy())
+	}
+	for i := 0; i < x.Len(); i++ {
+		elements = append(elements, x.Elem(i).Copy())
+	}
+	ret := GotaSeries[T]{
+		Name:     s.Name,
+		elements: elementsOf(elements),
+	}
+	return &ret
 }
 
 // Subset returns a subset of the series based on the given Indexes.
@@ -253,9 +296,30 @@ func (s *GotaSeries[T]) Records() []string {
 // NaN.
 func (s *GotaSeries[T]) Float() []float64 {
 	ret := make([]float64, s.Len())
+	if fe, ok := s.elements.(*FlatElements[T]); ok {
+		if floats, ok := any(fe.values).([]float64); ok {
+			copy(ret, floats)
+			for w, word := range fe.nan {
+				for b := 0; word != 0; b++ {
+					if word&1 != 0 {
+						ret[w*64+b] = math.NaN()
+					}
+					word >>= 1
+				}
+			}
+			return ret
+		}
+		for i, v := range fe.values {
+			if fe.isNA(i) {
+				ret[i] = math.NaN()
+				continue
+			}
+			ret[i] = toFloat(v)
+		}
+		return ret
+	}
 	for i := 0; i < s.Len(); i++ {
-		e := s.elements.Elem(i)
-		ret[i] = e.Float()
+		ret[i] = elementFloat(s.elements.Elem(i))
 	}
 	return ret
 }
@@ -336,6 +400,27 @@ func (s *GotaSeries[T]) Elem(i int) Element[T] {
 	return s.elements.Elem(i)
 }
 
+// ElemSafe returns the element of a series for the given index, or an error if
+// the index is out of bounds.
+func (s *GotaSeries[T]) ElemSafe(i int) (Element[T], error) {
+	if i < 0 || i >= s.Len() {
+		return nil, fmt.Errorf("elem error: index %d out of range [0, %d)", i, s.Len())
+	}
+	return s.elements.Elem(i), nil
+}
+
+// All returns an iterator over the elements of the Series, yielding the index
+// of every element along with the element itself.
+func (s *GotaSeries[T]) All() func(yield func(int, Element[T]) bool) {
+	return func(yield func(int, Element[T]) bool) {
+		for i := 0; i < s.Len(); i++ {
+			if !yield(i, s.elements.Elem(i)) {
+				return
+			}
+		}
+	}
+}
+
 // parseIndexes will parse the given indexes for a given series of length `l`. No
 // out of bounds checks is performed.
 func parseIndexes(l int, indexes Indexes) ([]int, error) {
@@ -363,22 +448,23 @@ func parseIndexes(l int, indexes Indexes) ([]int, error) {
 		if s.HasNaN() {
 			return nil, fmt.Errorf("indexing error: indexes contain NaN")
 		}
-
-		// TODO: complete this
-		switch s.t {
-		case Int:
-			return s.Int()
-		case Bool:
-			bools, err := s.Bool()
-			if err != nil {
-				return nil, fmt.Errorf("indexing error: %v", err)
-			}
-			return parseIndexes(l, bools)
-		default:
-			return nil, fmt.Errorf("indexing error: unknown indexing mode")
+		idx = make([]int, s.Len())
+		for i := range idx {
+			idx[i] = s.Val(i)
+		}
+	case BoolSeries:
+		s := idxs
+		if err := s.Error(); err != nil {
+			return nil, fmt.Errorf("indexing error: new values has errors: %v", err)
+		}
+		if s.HasNaN() {
+			return nil, fmt.Errorf("indexing error: indexes contain NaN")
+		}
+		bools := make([]bool, s.Len())
+		for i := range bools {
+			bools[i] = s.Val(i)
 		}
-	case Series[bool]:
-		// TODO: complete this
+		return parseIndexes(l, bools)
 	default:
 		return nil, fmt.Errorf("indexing error: unknown indexing mode")
 	}
@@ -423,6 +509,59 @@ func (s *GotaSeries[T]) Mean() float64 {
 	return stdDev
 }
 
+// Var calculates the sample variance of the non NaN elements of a series. It
+// returns NaN for String series and for series with less than two non NaN
+// elements.
+func (s *GotaSeries[T]) Var() float64 {
+	if !isNumeric[T]() {
+		return math.NaN()
+	}
+	floats := s.nonNAFloats()
+	if len(floats) < 2 {
+		return math.NaN()
+	}
+	return stat.Variance(floats, nil)
+}
+
+// Skewness calculates the sample skewness of the non NaN elements of a series.
+// It returns NaN for String series and for series with less than three non NaN
+// elements.
+func (s *GotaSeries[T]) Skewness() float64 {
+	if !isNumeric[T]() {
+		return math.NaN()
+	}
+	floats := s.nonNAFloats()
+	if len(floats) < 3 {
+		return math.NaN()
+	}
+	return stat.Skew(floats, nil)
+}
+
+// Kurtosis calculates the sample excess kurtosis of the non NaN elements of a
+// series, so a normal distribution has a kurtosis of zero. It returns NaN for
+// String series and for series with less than four non NaN elements.
+func (s *GotaSeries[T]) Kurtosis() float64 {
+	if !isNumeric[T]() {
+		return math.NaN()
+	}
+	floats := s.nonNAFloats()
+	if len(floats) < 4 {
+		return math.NaN()
+	}
+	return stat.ExKurtosis(floats, nil)
+}
+
+// nonNAFloats returns the non NaN elements of a series as float64.
+func (s *GotaSeries[T]) nonNAFloats() []float64 {
+	var floats []float64
+	for i := 0; i < s.Len(); i++ {
+		if f := elementFloat(s.elements.Elem(i)); !math.IsNaN(f) {
+			floats = append(floats, f)
+		}
+	}
+	return floats
+}
+
 // Median calculates the middle or median value, as opposed to
 // mean, and there is less susceptible to being affected by outliers.
 func (s *GotaSeries[T]) Median() float64 {
@@ -544,14 +683,12 @@ func (s *GotaSeries[T]) Map(f MapFunction[T]) Series[T] {
 
 // Sum calculates the sum value of a series
 func (s *GotaSeries[T]) Sum() float64 {
-	if s.elements.Len() == 0 || s.Type() == String || s.Type() == Bool {
+	if s.elements.Len() == 0 || !isNumeric[T]() {
 		return math.NaN()
 	}
-	sFloat := s.Float()
-	sum := sFloat[0]
-	for i := 1; i < len(sFloat); i++ {
-		elem := sFloat[i]
-		sum += elem
+	sum := 0.0
+	for _, f := range s.Float() {
+		sum += f
 	}
 	return sum
 }
@@ -575,3 +712,612 @@ func (s *GotaSeries[T]) Slice(j, k int) Series[T] {
 
 	return s.Subset(idxs)
 }
+
+// Shift returns a new Series with its elements shifted n positions forward, or
+// backward if n is negative. The vacated positions are filled with NaN, so
+// the length of the Series is preserved.
+func (s *GotaSeries[T]) Shift(n int) Series[T] {
+	if s.Err != nil {
+		return s
+	}
+
+	length := s.Len()
+	elements := make([]Element[T], length)
+	for i := 0; i < length; i++ {
+		j := i - n
+		if j < 0 || j >= length {
+			elements[i] = NewNAElement[T]()
+			continue
+		}
+		elements[i] = s.elements.Elem(j).Copy()
+	}
+
+	ret := GotaSeries[T]{
+		Name:     s.Name,
+		elements: elementsOf(elements),
+	}
+	return &ret
+}
+
+// Diff returns the difference between each element of the Series and the
+// element n positions earlier. The first n elements of the result are NaN. Diff
+// is only defined for numeric Series.
+func (s *GotaSeries[T]) Diff(n int) Series[T] {
+	if s.Err != nil {
+		return s
+	}
+	if !isNumeric[T]() {
+		return newErrorSeries[T](s.Name, fmt.Errorf("diff: series is not numeric"))
+	}
+
+	shifted := s.Shift(n)
+	elements := make([]Element[T], s.Len())
+	for i := range elements {
+		a, b := s.elements.Elem(i), shifted.Elem(i)
+		if a.IsNA() || b.IsNA() {
+			elements[i] = NewNAElement[T]()
+			continue
+		}
+		elements[i] = NewElement(fromFloat[T](toFloat(a.Val()) - toFloat(b.Val())))
+	}
+
+	ret := GotaSeries[T]{
+		Name:     s.Name,
+		elements: elementsOf(elements),
+	}
+	return &ret
+}
+
+// CumSum returns the cumulative sum of the Series. NaN elements are kept as NaN
+// and skipped, carrying the previous accumulated value. CumSum is only defined
+// for numeric Series.
+func (s *GotaSeries[T]) CumSum() Series[T] {
+	if s.Err != nil {
+		return s
+	}
+	if !isNumeric[T]() {
+		return newErrorSeries[T](s.Name, fmt.Errorf("cumsum: series is not numeric"))
+	}
+	return s.cumulate(func(acc, v T) T {
+		return acc + v
+	})
+}
+
+// CumProd returns the cumulative product of the Series. NaN elements are kept
+// as NaN and skipped, carrying the previous accumulated value. CumProd is only
+// defined for numeric Series.
+func (s *GotaSeries[T]) CumProd() Series[T] {
+	if s.Err != nil {
+		return s
+	}
+	if !isNumeric[T]() {
+		return newErrorSeries[T](s.Name, fmt.Errorf("cumprod: series is not numeric"))
+	}
+	return s.cumulate(func(acc, v T) T {
+		return fromFloat[T](toFloat(acc) * toFloat(v))
+	})
+}
+
+// CumMax returns the cumulative maximum of the Series. NaN elements are kept
+// as NaN and skipped, carrying the previous accumulated value.
+func (s *GotaSeries[T]) CumMax() Series[T] {
+	if s.Err != nil {
+		return s
+	}
+	return s.cumulate(func(acc, v T) T {
+		if v > acc {
+			return v
+		}
+		return acc
+	})
+}
+
+// CumMin returns the cumulative minimum of the Series. NaN elements are kept
+// as NaN and skipped, carrying the previous accumulated value.
+func (s *GotaSeries[T]) CumMin() Series[T] {
+	if s.Err != nil {
+		return s
+	}
+	return s.cumulate(func(acc, v T) T {
+		if v < acc {
+			return v
+		}
+		return acc
+	})
+}
+
+// cumulate returns a Series with the running aggregation of the elements of the
+// Series, as computed by the given function.
+func (s *GotaSeries[T]) cumulate(f func(acc, v T) T) Series[T] {
+	elements := make([]Element[T], s.Len())
+	var acc T
+	started := false
+	for i := range elements {
+		e := s.elements.Elem(i)
+		if e.IsNA() {
+			elements[i] = NewNAElement[T]()
+			continue
+		}
+		if started {
+			acc = f(acc, e.Val())
+		} else {
+			acc = e.Val()
+			started = true
+		}
+		elements[i] = NewElement(acc)
+	}
+
+	ret := GotaSeries[T]{
+		Name:     s.Name,
+		elements: elementsOf(elements),
+	}
+	return &ret
+}
+
+// FillNAForward returns a copy of the Series where every NaN element is
+// replaced by the last non NaN element before it. Leading NaN elements are kept
+// as NaN.
+func (s *GotaSeries[T]) FillNAForward() Series[T] {
+	if s.Err != nil {
+		return s
+	}
+	return s.fillNA(false)
+}
+
+// FillNABackward returns a copy of the Series where every NaN element is
+// replaced by the next non NaN element after it. Trailing NaN elements are kept
+// as NaN.
+func (s *GotaSeries[T]) FillNABackward() Series[T] {
+	if s.Err != nil {
+		return s
+	}
+	return s.fillNA(true)
+}
+
+// fillNA propagates the non NaN elements of the Series into the following NaN
+// elements, walking the Series from the end if backward is set.
+func (s *GotaSeries[T]) fillNA(backward bool) Series[T] {
+	length := s.Len()
+	elements := make([]Element[T], length)
+	var last Element[T]
+	for k := 0; k < length; k++ {
+		i := k
+		if backward {
+			i = length - 1 - k
+		}
+		e := s.elements.Elem(i)
+		switch {
+		case !e.IsNA():
+			last = e
+			elements[i] = e.Copy()
+		case last != nil:
+			elements[i] = last.Copy()
+		default:
+			elements[i] = NewNAElement[T]()
+		}
+	}
+
+	ret := GotaSeries[T]{
+		Name:     s.Name,
+		elements: elementsOf(elements),
+	}
+	return &ret
+}
+
+// ValueCounts returns the distinct values of the Series, in a Series named
+// "values", along with the number of times each of them appears, in an Int
+// Series named "counts". Both are sorted in descending order of count, ties
+// keeping the order in which values are first seen. NaN elements are counted
+// as a category of their own unless dropNA is given as true.
+//
+// dataframe.ValueCounts returns the same result as a DataFrame.
+func (s *GotaSeries[T]) ValueCounts(dropNA ...bool) (Series[T], Series[int]) {
+	if s.Err != nil {
+		return newErrorSeries[T]("values", s.Err), newErrorSeries[int]("counts", s.Err)
+	}
+	skipNA := len(dropNA) > 0 && dropNA[0]
+
+	var elements []Element[T]
+	var counts []int
+	pos := make(map[T]int)
+	naPos := -1
+	for i := 0; i < s.Len(); i++ {
+		e := s.elements.Elem(i)
+		if e.IsNA() {
+			if skipNA {
+				continue
+			}
+			if naPos < 0 {
+				naPos = len(elements)
+				elements = append(elements, NewNAElement[T]())
+				counts = append(counts, 0)
+			}
+			counts[naPos]++
+			continue
+		}
+		p, ok := pos[e.Val()]
+		if !ok {
+			p = len(elements)
+			pos[e.Val()] = p
+			elements = append(elements, e.Copy())
+			counts = append(counts, 0)
+		}
+		counts[p]++
+	}
+
+	order := make([]int, len(elements))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return counts[order[a]] > counts[order[b]]
+	})
+	sortedElements := make([]Element[T], len(order))
+	sortedCounts := make([]int, len(order))
+	for i, j := range order {
+		sortedElements[i] = elements[j]
+		sortedCounts[i] = counts[j]
+	}
+
+	values := GotaSeries[T]{
+		Name:     "values",
+		elements: elementsOf(sortedElements),
+	}
+	return &values, NewSeries("counts", sortedCounts...)
+}
+
+// Unique returns a Series with the distinct elements of the Series, in the
+// order in which they are first seen. All NaN elements are considered equal,
+// so at most one NaN element is kept.
+func (s *GotaSeries[T]) Unique() Series[T] {
+	if s.Err != nil {
+		return s
+	}
+
+	var elements []Element[T]
+	seen := make(map[T]bool)
+	seenNA := false
+	for i := 0; i < s.Len(); i++ {
+		e := s.elements.Elem(i)
+		if e.IsNA() {
+			if !seenNA {
+				seenNA = true
+				elements = append(elements, NewNAElement[T]())
+			}
+			continue
+		}
+		if !seen[e.Val()] {
+			seen[e.Val()] = true
+			elements = append(elements, e.Copy())
+		}
+	}
+
+	ret := GotaSeries[T]{
+		Name:     s.Name,
+		elements: elementsOf(elements),
+	}
+	return &ret
+}
+
+// NUnique returns the number of distinct elements of the Series. NaN elements
+// count as a single distinct value unless dropNA is set.
+func (s *GotaSeries[T]) NUnique(dropNA bool) int {
+	n := 0
+	seen := make(map[T]bool)
+	seenNA := false
+	for i := 0; i < s.Len(); i++ {
+		e := s.elements.Elem(i)
+		if e.IsNA() {
+			if !dropNA && !seenNA {
+				seenNA = true
+				n++
+			}
+			continue
+		}
+		if !seen[e.Val()] {
+			seen[e.Val()] = true
+			n++
+		}
+	}
+	return n
+}
+
+// Apply applies the given function to every element of the Series and returns
+// a new Series with the given name holding the results. Unlike Map, the
+// resulting Series can be of a different type than the original.
+func Apply[T, U SeriesType](s Series[T], f func(Element[T]) U, name string) Series[U] {
+	if err := s.Error(); err != nil {
+		return newErrorSeries[U](name, err)
+	}
+	values := make([]U, s.Len())
+	for i := range values {
+		values[i] = f(s.Elem(i))
+	}
+	return NewSeries(name, values...)
+}
+
+// ApplyBool is the equivalent of Apply for functions returning a bool, whose
+// results are stored on a BoolSeries.
+func ApplyBool[T SeriesType](s Series[T], f func(Element[T]) bool, name string) BoolSeries {
+	if err := s.Error(); err != nil {
+		return &GotaBoolSeries{Name: name, Err: err}
+	}
+	values := make([]bool, s.Len())
+	for i := range values {
+		values[i] = f(s.Elem(i))
+	}
+	return NewBoolSeries(name, values...)
+}
+
+// Between returns a BoolSeries that is true for the elements within the range
+// between low and high, including the bounds if inclusive is set. NaN elements
+// result in false.
+func (s *GotaSeries[T]) Between(low, high T, inclusive bool) BoolSeries {
+	return ApplyBool[T](s, func(e Element[T]) bool {
+		if e.IsNA() {
+			return false
+		}
+		if inclusive {
+			return low <= e.Val() && e.Val() <= high
+		}
+		return low < e.Val() && e.Val() < high
+	}, s.Name)
+}
+
+// Clip returns a new Series with its elements bounded to the [lower, upper]
+// range. NaN elements are left untouched. Elements are compared with the `<`
+// and `>` operators, so String Series are clipped lexicographically.
+func (s *GotaSeries[T]) Clip(lower, upper T) Series[T] {
+	if s.Err != nil {
+		return s
+	}
+	if lower > upper {
+		return newErrorSeries[T](s.Name, fmt.Errorf("clip: lower bound is greater than upper bound"))
+	}
+
+	elements := make([]Element[T], s.Len())
+	for i := range elements {
+		e := s.elements.Elem(i)
+		switch {
+		case e.IsNA():
+			elements[i] = NewNAElement[T]()
+		case e.Val() < lower:
+			elements[i] = NewElement(lower)
+		case e.Val() > upper:
+			elements[i] = NewElement(upper)
+		default:
+			elements[i] = e.Copy()
+		}
+	}
+
+	ret := GotaSeries[T]{
+		Name:     s.Name,
+		elements: elementsOf(elements),
+	}
+	return &ret
+}
+
+// Describe returns summary statistics of the Series, with the name of each
+// statistic in a Series named "statistic" and its value, formatted as a string,
+// in a Series named "value". Numeric Series are summarized by their count of
+// non NaN elements, mean, standard deviation, minimum, quartiles and maximum;
+// String Series by their count, number of unique values, most frequent value
+// (top) and its frequency (freq). Use dataframe.DescribeSeries to get them as a
+// DataFrame.
+func (s *GotaSeries[T]) Describe() (Series[string], Series[string]) {
+	if s.Err != nil {
+		return newErrorSeries[string]("statistic", s.Err), newErrorSeries[string]("value", s.Err)
+	}
+
+	if !isNumeric[T]() {
+		values, counts := s.ValueCounts(true)
+		count := 0
+		for i := 0; i < counts.Len(); i++ {
+			count += counts.Val(i)
+		}
+		top, freq := "NaN", "NaN"
+		if values.Len() > 0 {
+			top, freq = fmt.Sprint(values.Val(0)), strconv.Itoa(counts.Val(0))
+		}
+		return NewSeries("statistic", "count", "unique", "top", "freq"),
+			NewSeries("value", strconv.Itoa(count), strconv.Itoa(values.Len()), top, freq)
+	}
+
+	var floats []float64
+	for i := 0; i < s.Len(); i++ {
+		if f := elementFloat(s.elements.Elem(i)); !math.IsNaN(f) {
+			floats = append(floats, f)
+		}
+	}
+	sort.Float64s(floats)
+	stats := []float64{float64(len(floats)), math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN()}
+	if len(floats) > 0 {
+		stats[1] = stat.Mean(floats, nil)
+		stats[2] = stat.StdDev(floats, nil)
+		stats[3] = floats[0]
+		stats[4] = stat.Quantile(0.25, stat.Empirical, floats, nil)
+		stats[5] = stat.Quantile(0.50, stat.Empirical, floats, nil)
+		stats[6] = stat.Quantile(0.75, stat.Empirical, floats, nil)
+		stats[7] = floats[len(floats)-1]
+	}
+	values := make([]string, len(stats))
+	for i, v := range stats {
+		values[i] = strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return NewSeries("statistic", "count", "mean", "std", "min", "25%", "50%", "75%", "max"),
+		NewSeries("value", values...)
+}
+
+// WeightedMean computes the mean of the Series weighted by the given weights,
+// that is, sum(w*x)/sum(w). Observations where either the element or its
+// weight are NaN are excluded. An error is returned if the lengths of the
+// Series and the weights differ or if all weights are zero.
+func (s *GotaSeries[T]) WeightedMean(weights Series[float64]) (float64, error) {
+	if s.Err != nil {
+		return math.NaN(), s.Err
+	}
+	if err := weights.Error(); err != nil {
+		return math.NaN(), fmt.Errorf("weighted mean error: weights has errors: %v", err)
+	}
+	if s.Len() != weights.Len() {
+		return math.NaN(), fmt.Errorf("weighted mean error: dimensions mismatch")
+	}
+	var sum, sumWeights float64
+	for i := 0; i < s.Len(); i++ {
+		x := elementFloat(s.elements.Elem(i))
+		w := elementFloat(weights.Elem(i))
+		if math.IsNaN(x) || math.IsNaN(w) {
+			continue
+		}
+		sum += w * x
+		sumWeights += w
+	}
+	if sumWeights == 0 {
+		return math.NaN(), fmt.Errorf("weighted mean error: weights sum to zero")
+	}
+	return sum / sumWeights, nil
+}
+
+// Mode returns the most frequent values of the Series. When several values tie
+// for the highest frequency all of them are returned, in the order in which
+// they are first seen. NaN elements are ignored.
+func (s *GotaSeries[T]) Mode() Series[T] {
+	if s.Err != nil {
+		return newErrorSeries[T](s.Name, s.Err)
+	}
+	values, counts := s.ValueCounts(true)
+	var elements []Element[T]
+	for i := 0; i < counts.Len() && counts.Val(i) == counts.Val(0); i++ {
+		elements = append(elements, values.Elem(i))
+	}
+	ret := GotaSeries[T]{
+		Name:     s.Name,
+		elements: elementsOf(elements),
+	}
+	return &ret
+}
+
+// MinMaxScale returns a Float Series with the elements of the Series linearly
+// scaled to the [0, 1] range. NaN elements are kept as NaN and, when all
+// elements are equal, every element is scaled to zero.
+func (s *GotaSeries[T]) MinMaxScale() Series[float64] {
+	if s.Err != nil {
+		return newErrorSeries[float64](s.Name, s.Err)
+	}
+	floats := make([]float64, s.Len())
+	min, max := math.Inf(1), math.Inf(-1)
+	for i := range floats {
+		floats[i] = elementFloat(s.elements.Elem(i))
+		if !math.IsNaN(floats[i]) {
+			min = math.Min(min, floats[i])
+			max = math.Max(max, floats[i])
+		}
+	}
+	for i, f := range floats {
+		if math.IsNaN(f) {
+			continue
+		}
+		if max == min {
+			floats[i] = 0
+			continue
+		}
+		floats[i] = (f - min) / (max - min)
+	}
+	return NewSeries(s.Name, floats...)
+}
+
+// ZScore returns a Float Series with the elements of the Series standardized by
+// subtracting their mean and dividing by their sample standard deviation. NaN
+// elements are kept as NaN and don't contribute to the mean and standard
+// deviation. When all elements are equal, every element is scaled to zero.
+func (s *GotaSeries[T]) ZScore() Series[float64] {
+	if s.Err != nil {
+		return newErrorSeries[float64](s.Name, s.Err)
+	}
+	floats := make([]float64, s.Len())
+	var valid []float64
+	for i := range floats {
+		floats[i] = elementFloat(s.elements.Elem(i))
+		if !math.IsNaN(floats[i]) {
+			valid = append(valid, floats[i])
+		}
+	}
+	mean, std := stat.MeanStdDev(valid, nil)
+	for i, f := range floats {
+		if math.IsNaN(f) {
+			continue
+		}
+		if std == 0 || math.IsNaN(std) {
+			floats[i] = 0
+			continue
+		}
+		floats[i] = (f - mean) / std
+	}
+	return NewSeries(s.Name, floats...)
+}
+
+// Interpolate returns a Float Series where every NaN element lying between two
+// non NaN elements is replaced by the linear interpolation of them, based on
+// their positions. Leading and trailing NaN elements are kept as NaN.
+// Interpolate is only defined for numeric Series.
+func (s *GotaSeries[T]) Interpolate() Series[float64] {
+	if s.Err != nil {
+		return newErrorSeries[float64](s.Name, s.Err)
+	}
+	if !isNumeric[T]() {
+		return newErrorSeries[float64](s.Name, fmt.Errorf("interpolate: series is not numeric"))
+	}
+	floats := make([]float64, s.Len())
+	prev := -1
+	for i := range floats {
+		floats[i] = elementFloat(s.elements.Elem(i))
+		if math.IsNaN(floats[i]) {
+			continue
+		}
+		if prev >= 0 && i-prev > 1 {
+			step := (floats[i] - floats[prev]) / float64(i-prev)
+			for j := prev + 1; j < i; j++ {
+				floats[j] = floats[prev] + step*float64(j-prev)
+			}
+		}
+		prev = i
+	}
+	return NewSeries(s.Name, floats...)
+}
+
+// Reverse returns a new Series with the elements of the Series in reverse
+// order.
+func (s *GotaSeries[T]) Reverse() Series[T] {
+	if s.Err != nil {
+		return s
+	}
+	elements := make([]Element[T], s.Len())
+	for i := range elements {
+		elements[i] = s.elements.Elem(s.Len() - 1 - i).Copy()
+	}
+	ret := GotaSeries[T]{
+		Name:     s.Name,
+		elements: elementsOf(elements),
+	}
+	return &ret
+}
+
+// Shuffle returns a copy of the Series with its elements randomly permuted. The
+// given seed is used to initialize the random source, so that the same seed
+// always yields the same permutation, which is also the one applied to the
+// rows by DataFrame.Shuffle.
+func (s *GotaSeries[T]) Shuffle(seed int64) Series[T] {
+	if s.Err != nil {
+		return s
+	}
+	perm := rand.New(rand.NewSource(seed)).Perm(s.Len())
+	elements := make([]Element[T], len(perm))
+	for i, j := range perm {
+		elements[i] = s.elements.Elem(j).Copy()
+	}
+	ret := GotaSeries[T]{
+		Name:     s.Name,
+		elements: elementsOf(elements),
+	}
+	return &ret
+}
@@ -0,0 +1,71 @@
+package series
+
+import (
+	"math"
+	"sort"
+)
+
+// RankMethod defines how tied elements are ranked by Series.Rank.
+type RankMethod int
+
+// Supported RankMethods
+const (
+	RankAverage RankMethod = iota // Average rank of the tied group
+	RankMin                       // Lowest rank of the tied group
+	RankMax                       // Highest rank of the tied group
+	RankFirst                     // Ranks assigned in order of appearance
+	RankDense                     // Like RankMin, but ranks increase by 1 between groups
+)
+
+// Rank returns the 1-based rank of every element of the Series, in ascending
+// order or in descending order if reverse is set. Tied elements are ranked
+// according to the given RankMethod. NaN elements are ranked as NaN and don't
+// affect the rank of the rest of elements.
+func (s *GotaSeries[T]) Rank(method RankMethod, reverse bool) Series[float64] {
+	if s.Err != nil {
+		return newErrorSeries[float64](s.Name, s.Err)
+	}
+
+	ranks := make([]float64, s.Len())
+	var idx []int
+	for i := range ranks {
+		ranks[i] = math.NaN()
+		if !s.elements.Elem(i).IsNA() {
+			idx = append(idx, i)
+		}
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		if reverse {
+			return s.Val(idx[a]) > s.Val(idx[b])
+		}
+		return s.Val(idx[a]) < s.Val(idx[b])
+	})
+
+	dense := 0
+	for start := 0; start < len(idx); {
+		end := start + 1
+		for end < len(idx) && s.Val(idx[end]) == s.Val(idx[start]) {
+			end++
+		}
+		dense++
+		for k := start; k < end; k++ {
+			var rank float64
+			switch method {
+			case RankMin:
+				rank = float64(start + 1)
+			case RankMax:
+				rank = float64(end)
+			case RankFirst:
+				rank = float64(k + 1)
+			case RankDense:
+				rank = float64(dense)
+			default:
+				rank = float64(start+1+end) / 2
+			}
+			ranks[idx[k]] = rank
+		}
+		start = end
+	}
+
+	return NewSeries(s.Name, ranks...)
+}
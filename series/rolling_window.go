@@ -1,52 +1,158 @@
 package series
 
-// RollingWindow is used for rolling window calculations.
-type RollingWindow struct {
+import (
+	"fmt"
+	"math"
+
+	"gonum.org/v1/gonum/stat"
+)
+
+// RollingSeries is used for rolling window calculations. Every calculation
+// returns a Series of the same length as the original one, where the first
+// window-1 elements are NaN.
+type RollingSeries interface {
+	Mean() Series[float64]
+	Sum() Series[float64]
+	Max() Series[float64]
+	Min() Series[float64]
+	StdDev() Series[float64]
+}
+
+// RollingWindow implements RollingSeries for a Series of type T.
+type RollingWindow[T SeriesType] struct {
 	window int
-	series Series1
+	series Series[T]
+	err    error
 }
 
 // Rolling creates new RollingWindow
-func (s Series1) Rolling(window int) RollingWindow {
-	return RollingWindow{
+func (s *GotaSeries[T]) Rolling(window int) RollingSeries {
+	r := RollingWindow[T]{
 		window: window,
 		series: s,
+		err:    s.Err,
 	}
+	if r.err == nil && (window <= 0 || window > s.Len()) {
+		r.err = fmt.Errorf("rolling: window %d out of range for series of length %d", window, s.Len())
+	}
+	return r
 }
 
 // Mean returns the rolling mean.
-func (r RollingWindow) Mean() (s Series1) {
-	s = New([]float64{}, Float, "Mean")
-	for _, block := range r.getBlocks() {
-		s.Append(block.Mean())
-	}
+func (r RollingWindow[T]) Mean() Series[float64] {
+	return r.apply("Mean", func(block []float64) float64 {
+		return stat.Mean(block, nil)
+	})
+}
 
-	return
+// Sum returns the rolling sum.
+func (r RollingWindow[T]) Sum() Series[float64] {
+	return r.apply("Sum", func(block []float64) float64 {
+		sum := 0.0
+		for _, v := range block {
+			sum += v
+		}
+		return sum
+	})
 }
 
-// StdDev returns the rolling mean.
-func (r RollingWindow) StdDev() (s Series1) {
-	s = New([]float64{}, Float, "StdDev")
-	for _, block := range r.getBlocks() {
-		s.Append(block.StdDev())
-	}
+// Max returns the rolling maximum.
+func (r RollingWindow[T]) Max() Series[float64] {
+	return r.apply("Max", func(block []float64) float64 {
+		max := block[0]
+		for _, v := range block[1:] {
+			if v > max {
+				max = v
+			}
+		}
+		return max
+	})
+}
 
-	return
+// Min returns the rolling minimum.
+func (r RollingWindow[T]) Min() Series[float64] {
+	return r.apply("Min", func(block []float64) float64 {
+		min := block[0]
+		for _, v := range block[1:] {
+			if v < min {
+				min = v
+			}
+		}
+		return min
+	})
 }
 
-func (r RollingWindow) getBlocks() (blocks []Series1) {
-	for i := 1; i <= r.series.Len(); i++ {
-		if i < r.window {
-			blocks = append(blocks, r.series.Empty())
+// StdDev returns the rolling standard deviation.
+func (r RollingWindow[T]) StdDev() Series[float64] {
+	return r.apply("StdDev", func(block []float64) float64 {
+		return stat.StdDev(block, nil)
+	})
+}
+
+// RollingApply returns a Float Series with the result of applying f to every
+// window of the given size, passed as a Series holding its own copies of the
+// elements of the window.
+// Unlike the RollingSeries calculations, windows containing NaN elements are
+// also passed to f. The first window-1 elements of the result are NaN.
+func (s *GotaSeries[T]) RollingApply(window int, f func(Series[T]) float64) Series[float64] {
+	if s.Err != nil {
+		return newErrorSeries[float64](s.Name, s.Err)
+	}
+	if window <= 0 || window > s.Len() {
+		return newErrorSeries[float64](s.Name, fmt.Errorf("rolling apply: window %d out of range for series of length %d", window, s.Len()))
+	}
+
+	ret := make([]float64, s.Len())
+	for i := range ret {
+		if i < window-1 {
+			ret[i] = math.NaN()
 			continue
 		}
+		// Every window gets its own copies, so f can modify them freely
+		elements := make([]Element[T], window)
+		for k := range elements {
+			elements[k] = s.elements.Elem(i - window + 1 + k).Copy()
+		}
+		block := GotaSeries[T]{
+			Name:     s.Name,
+			elements: elementsOf(elements),
+		}
+		ret[i] = f(&block)
+	}
+	return NewSeries(s.Name, ret...)
+}
+
+// apply computes f over every block of the window. Blocks containing NaN
+// elements result in NaN.
+func (r RollingWindow[T]) apply(name string, f func(block []float64) float64) Series[float64] {
+	if r.err != nil {
+		return newErrorSeries[float64](name, r.err)
+	}
 
-		index := []int{}
-		for j := i - r.window; j < i; j++ {
-			index = append(index, j)
+	values := make([]float64, r.series.Len())
+	for i := range values {
+		values[i] = elementFloat(r.series.Elem(i))
+	}
+	ret := make([]float64, len(values))
+	for i := range values {
+		if i < r.window-1 {
+			ret[i] = math.NaN()
+			continue
+		}
+		block := values[i-r.window+1 : i+1]
+		ret[i] = math.NaN()
+		if !hasNaN(block) {
+			ret[i] = f(block)
 		}
-		blocks = append(blocks, r.series.Subset(index))
 	}
+	return NewSeries(name, ret...)
+}
 
-	return
+func hasNaN(values []float64) bool {
+	for _, v := range values {
+		if math.IsNaN(v) {
+			return true
+		}
+	}
+	return false
 }
@@ -2,84 +2,187 @@ package series
 
 import (
 	"math"
-	"strings"
+	"reflect"
 	"testing"
 )
 
+// checkRolling compares the values of a rolling calculation with the expected
+// ones, with NaN elements matching math.NaN().
+func checkRolling(t *testing.T, testnum int, expected []float64, received Series[float64]) {
+	if err := received.Error(); err != nil {
+		t.Errorf("Test:%v\nError:%v", testnum, err)
+		return
+	}
+	if received.Len() != len(expected) {
+		t.Errorf(
+			"Test:%v\nExpected:\n%v\nReceived:\n%v",
+			testnum, expected, seriesVals(received),
+		)
+		return
+	}
+	for i, e := range expected {
+		r := math.NaN()
+		if !received.Elem(i).IsNA() {
+			r = received.Val(i)
+		}
+		if !compareFloats(e, r, 6) {
+			t.Errorf(
+				"Test:%v\nExpected:\n%v\nReceived:\n%v",
+				testnum, expected, seriesVals(received),
+			)
+			return
+		}
+	}
+}
+
 func TestSeries_RollingMean(t *testing.T) {
 	tests := []struct {
 		window   int
-		series   Series1
-		expected Series1
+		series   Series[float64]
+		expected []float64
 	}{
 		{
 			3,
-			Ints([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}),
-			Floats([]float64{math.NaN(), math.NaN(), 2.0, 3.0, 4.0, 5.0, 6.0, 7.0, 8.0, 9.0}),
+			Floats(1, 2, 3, 4, 5, 6, 7, 8, 9, 10),
+			[]float64{math.NaN(), math.NaN(), 2.0, 3.0, 4.0, 5.0, 6.0, 7.0, 8.0, 9.0},
 		},
 		{
 			2,
-			Floats([]float64{1.0, 2.0, 3.0}),
-			Floats([]float64{math.NaN(), 1.5, 2.5}),
+			Floats(1.0, 2.0, 3.0),
+			[]float64{math.NaN(), 1.5, 2.5},
 		},
 		{
-			0,
-			Floats([]float64{}),
-			Floats([]float64{}),
+			3,
+			Floats(1.0, 2.0, math.NaN(), 4.0, 5.0, 6.0),
+			[]float64{math.NaN(), math.NaN(), math.NaN(), math.NaN(), math.NaN(), 5.0},
 		},
 	}
 
 	for testnum, test := range tests {
-		expected := test.expected
 		received := test.series.Rolling(test.window).Mean()
-
-		for i := 0; i < expected.Len(); i++ {
-			if strings.Compare(expected.Elem(i).String(),
-				received.Elem(i).String()) != 0 {
-				t.Errorf(
-					"Test:%v\nExpected:\n%v\nReceived:\n%v",
-					testnum, expected, received,
-				)
-			}
-		}
+		checkRolling(t, testnum, test.expected, received)
 	}
+
+	received := Ints(1, 2, 3, 4).Rolling(3).Mean()
+	checkRolling(t, len(tests), []float64{math.NaN(), math.NaN(), 2.0, 3.0}, received)
 }
 
 func TestSeries_RollingStdDev(t *testing.T) {
 	tests := []struct {
 		window   int
-		series   Series1
-		expected Series1
+		series   Series[float64]
+		expected []float64
 	}{
 		{
 			3,
-			Ints([]int{5, 5, 6, 7, 5, 5, 5}),
-			Floats([]float64{math.NaN(), math.NaN(), 0.5773502691896257, 1.0, 1.0, 1.1547005383792515, 0.0}),
+			Floats(5, 5, 6, 7, 5, 5, 5),
+			[]float64{math.NaN(), math.NaN(), 0.5773502691896257, 1.0, 1.0, 1.1547005383792515, 0.0},
 		},
 		{
 			2,
-			Floats([]float64{1.0, 2.0, 3.0}),
-			Floats([]float64{math.NaN(), 0.7071067811865476, 0.7071067811865476}),
+			Floats(1.0, 2.0, 3.0),
+			[]float64{math.NaN(), 0.7071067811865476, 0.7071067811865476},
+		},
+	}
+
+	for testnum, test := range tests {
+		received := test.series.Rolling(test.window).StdDev()
+		checkRolling(t, testnum, test.expected, received)
+	}
+}
+
+func TestSeries_RollingSumMaxMin(t *testing.T) {
+	s := Floats(1.0, 5.0, 3.0, 2.0, 4.0)
+	nan := math.NaN()
+	tests := []struct {
+		received Series[float64]
+		expected []float64
+	}{
+		{
+			s.Rolling(3).Sum(),
+			[]float64{nan, nan, 9.0, 10.0, 9.0},
+		},
+		{
+			s.Rolling(3).Max(),
+			[]float64{nan, nan, 5.0, 5.0, 4.0},
 		},
 		{
-			0,
-			Floats([]float64{}),
-			Floats([]float64{}),
+			s.Rolling(3).Min(),
+			[]float64{nan, nan, 1.0, 2.0, 2.0},
+		},
+		{
+			s.Rolling(5).Sum(),
+			[]float64{nan, nan, nan, nan, 15.0},
 		},
 	}
 
 	for testnum, test := range tests {
-		expected := test.expected
-		received := test.series.Rolling(test.window).StdDev()
+		checkRolling(t, testnum, test.expected, test.received)
+	}
+}
+
+func TestSeries_RollingErrors(t *testing.T) {
+	s := Floats(1.0, 2.0, 3.0)
+	for _, window := range []int{0, -1, 4} {
+		if err := s.Rolling(window).Mean().Error(); err == nil {
+			t.Errorf("Expected error for window %d", window)
+		}
+	}
+}
+
+func TestSeries_RollingApply(t *testing.T) {
+	valueRange := func(s Series[float64]) float64 {
+		min, max := math.Inf(1), math.Inf(-1)
+		for i := 0; i < s.Len(); i++ {
+			min = math.Min(min, s.Val(i))
+			max = math.Max(max, s.Val(i))
+		}
+		return max - min
+	}
+	weighted := func(s Series[float64]) float64 {
+		sum, weights := 0.0, 0.0
+		for i := 0; i < s.Len(); i++ {
+			sum += float64(i+1) * s.Val(i)
+			weights += float64(i + 1)
+		}
+		return sum / weights
+	}
+	nan := math.NaN()
+	tests := []struct {
+		window   int
+		f        func(Series[float64]) float64
+		expected []float64
+	}{
+		{2, valueRange, []float64{nan, 3, 2, 5, 1}},
+		{3, valueRange, []float64{nan, nan, 3, 5, 6}},
+		{1, valueRange, []float64{0, 0, 0, 0, 0}},
+		{3, weighted, []float64{nan, nan, (1 + 8 + 6) / 6.0, (4 + 4 + 21) / 6.0, (2 + 14 + 24) / 6.0}},
+	}
+	s := Floats(1, 4, 2, 7, 8)
+	for testnum, test := range tests {
+		checkRolling(t, testnum, test.expected, s.RollingApply(test.window, test.f))
+	}
+
+	// Windows are isolated from each other and from the original Series, so
+	// modifying them doesn't change the following windows
+	ints := Ints(1, 2, 3, 4)
+	sums := ints.RollingApply(2, func(s Series[int]) float64 {
+		sum := 0
+		for i := 0; i < s.Len(); i++ {
+			sum += s.Val(i)
+			s.Elem(i).Set(100)
+		}
+		s.Append(10)
+		return float64(sum)
+	})
+	checkRolling(t, len(tests), []float64{nan, 3, 5, 7}, sums)
+	if expected := []interface{}{1, 2, 3, 4}; !reflect.DeepEqual(expected, seriesVals(ints)) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, seriesVals(ints))
+	}
 
-		for i := 0; i < expected.Len(); i++ {
-			if strings.Compare(expected.Elem(i).String(),
-				received.Elem(i).String()) != 0 {
-				t.Errorf(
-					"Test:%v\nExpected:\n%v\nReceived:\n%v",
-					testnum, expected, received,
-				)
-			}
+	for _, window := range []int{0, -1, 6} {
+		if err := s.RollingApply(window, valueRange).Error(); err == nil {
+			t.Errorf("Expected error for window %d", window)
 		}
 	}
 }
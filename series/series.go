@@ -15,6 +15,8 @@ type Series[T SeriesType] interface {
 	Empty() Series[T]
 	Error() error
 	Append(item ...T)
+	AppendNA()
+	Grow(n int)
 	Concat(x Series[T]) Series[T]
 	Subset(indexes Indexes) Series[T]
 	Set(indexes Indexes, newvalues Series[T]) Series[T]
@@ -29,9 +31,14 @@ type Series[T SeriesType] interface {
 	Val(i int) T
 	Values() Elements[T]
 	Elem(i int) Element[T]
+	ElemSafe(i int) (Element[T], error)
+	All() func(yield func(int, Element[T]) bool)
 	Order(reverse bool) []int
 	StdDev() float64
 	Mean() float64
+	Var() float64
+	Skewness() float64
+	Kurtosis() float64
 	Median() float64
 	Max() float64
 	MaxStr() string
@@ -41,6 +48,34 @@ type Series[T SeriesType] interface {
 	Map(f MapFunction[T]) Series[T]
 	Sum() float64
 	Slice(j, k int) Series[T]
+	Shift(n int) Series[T]
+	Diff(n int) Series[T]
+	CumSum() Series[T]
+	CumProd() Series[T]
+	CumMax() Series[T]
+	CumMin() Series[T]
+	FillNAForward() Series[T]
+	FillNABackward() Series[T]
+	Rolling(window int) RollingSeries
+	RollingApply(window int, f func(Series[T]) float64) Series[float64]
+	Expanding() ExpandingSeries
+	StrOps() StrAccessor
+	Rank(method RankMethod, reverse bool) Series[float64]
+	ValueCounts(dropNA ...bool) (Series[T], Series[int])
+	Unique() Series[T]
+	NUnique(dropNA bool) int
+	Clip(lower, upper T) Series[T]
+	Between(low, high T, inclusive bool) BoolSeries
+	Cut(bins []float64, labels []string) Series[string]
+	QCut(q int) Series[string]
+	Describe() (Series[string], Series[string])
+	WeightedMean(weights Series[float64]) (float64, error)
+	Mode() Series[T]
+	MinMaxScale() Series[float64]
+	ZScore() Series[float64]
+	Interpolate() Series[float64]
+	Reverse() Series[T]
+	Shuffle(seed int64) Series[T]
 }
 
 // Indexes represent the elements that can be used for selecting a subset of
@@ -53,6 +88,10 @@ type Series[T SeriesType] interface {
 //	Series [Bool]  // Same as []bool
 type Indexes interface{}
 
+// Uint is the Type of the Series holding unsigned integers, as detected for
+// columns with integers that exceed the range of int.
+const Uint Type = "uint"
+
 // Strings is a constructor for a String Series
 func Strings(values ...string) Series[string] {
 	return NewSeries("", values...)
@@ -63,6 +102,11 @@ func Ints(values ...int) Series[int] {
 	return NewSeries("", values...)
 }
 
+// Uints is a constructor for a Uint Series
+func Uints(values ...uint) Series[uint] {
+	return NewSeries("", values...)
+}
+
 // Floats is a constructor for a Float Series
 func Floats(values ...float64) Series[float64] {
 	return NewSeries("", values...)
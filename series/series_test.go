@@ -3,9 +3,14 @@ package series
 import (
 	"fmt"
 	"math"
+	"math/rand"
 	"reflect"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
+
+	"gonum.org/v1/gonum/stat"
 )
 
 // Check that there are no shared memory addreses between the elements of two Series
@@ -1249,6 +1254,33 @@ func TestSeries_IsNaN(t *testing.T) {
 	}
 }
 
+func TestNewElement_NaN(t *testing.T) {
+	tests := []struct {
+		element  interface{ IsNA() bool }
+		expected bool
+	}{
+		{NewElement(1.5), false},
+		{NewElement(math.NaN()), true},
+		{NewElement(math.Inf(1)), false},
+		{NewElement(float32(math.NaN())), true},
+		{NewElement(0), false},
+		{NewElement("NaN"), false},
+	}
+	for testnum, test := range tests {
+		if received := test.element.IsNA(); received != test.expected {
+			t.Errorf(
+				"Test:%v\nExpected:\n%v\nReceived:\n%v",
+				testnum, test.expected, received,
+			)
+		}
+	}
+
+	expected := []bool{false, true, false}
+	if received := Floats(1.0, math.NaN(), 2.0).IsNaN(); !reflect.DeepEqual(expected, received) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, received)
+	}
+}
+
 func TestSeries_StdDev(t *testing.T) {
 	tests := []struct {
 		series   Series1
@@ -1835,3 +1867,1367 @@ func TestSeries_Slice(t *testing.T) {
 		}
 	}
 }
+
+// seriesVals returns the values of a Series as an []interface{}, using nil for
+// NaN elements.
+func seriesVals[T SeriesType](s Series[T]) []interface{} {
+	ret := make([]interface{}, s.Len())
+	for i := 0; i < s.Len(); i++ {
+		if !s.Elem(i).IsNA() {
+			ret[i] = s.Val(i)
+		}
+	}
+	return ret
+}
+
+func TestSeries_Shift(t *testing.T) {
+	tests := []struct {
+		n        int
+		series   Series[int]
+		expected []interface{}
+	}{
+		{
+			1,
+			Ints(1, 2, 3),
+			[]interface{}{nil, 1, 2},
+		},
+		{
+			-1,
+			Ints(1, 2, 3),
+			[]interface{}{2, 3, nil},
+		},
+		{
+			0,
+			Ints(1, 2, 3),
+			[]interface{}{1, 2, 3},
+		},
+		{
+			3,
+			Ints(1, 2, 3),
+			[]interface{}{nil, nil, nil},
+		},
+		{
+			-5,
+			Ints(1, 2, 3),
+			[]interface{}{nil, nil, nil},
+		},
+		{
+			1,
+			Ints(),
+			[]interface{}{},
+		},
+	}
+
+	for testnum, test := range tests {
+		received := test.series.Shift(test.n)
+		if err := received.Error(); err != nil {
+			t.Errorf("Test:%v\nError:%v", testnum, err)
+		}
+		if !reflect.DeepEqual(test.expected, seriesVals(received)) {
+			t.Errorf(
+				"Test:%v\nExpected:\n%v\nReceived:\n%v",
+				testnum, test.expected, seriesVals(received),
+			)
+		}
+	}
+}
+
+func TestSeries_Diff(t *testing.T) {
+	ints := Ints(2, 5, 9)
+	received := seriesVals(ints.Diff(1))
+	expected := []interface{}{nil, 3, 4}
+	if !reflect.DeepEqual(expected, received) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, received)
+	}
+
+	floats := Floats(2.0, 5.5, 9.0, 10.0)
+	received = seriesVals(floats.Diff(1))
+	expected = []interface{}{nil, 3.5, 3.5, 1.0}
+	if !reflect.DeepEqual(expected, received) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, received)
+	}
+	received = seriesVals(floats.Diff(2))
+	expected = []interface{}{nil, nil, 7.0, 4.5}
+	if !reflect.DeepEqual(expected, received) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, received)
+	}
+
+	strs := Strings("a", "b")
+	if err := strs.Diff(1).Error(); err == nil {
+		t.Errorf("Expected error for String series")
+	}
+}
+
+func TestSeries_Cumulative(t *testing.T) {
+	floats := Floats(1.0, 3.0, math.NaN(), 2.0, 4.0)
+	tests := []struct {
+		received Series[float64]
+		expected []interface{}
+	}{
+		{
+			floats.CumSum(),
+			[]interface{}{1.0, 4.0, nil, 6.0, 10.0},
+		},
+		{
+			floats.CumProd(),
+			[]interface{}{1.0, 3.0, nil, 6.0, 24.0},
+		},
+		{
+			floats.CumMax(),
+			[]interface{}{1.0, 3.0, nil, 3.0, 4.0},
+		},
+		{
+			floats.CumMin(),
+			[]interface{}{1.0, 1.0, nil, 1.0, 1.0},
+		},
+		{
+			Floats(math.NaN(), 2.0, 1.0).CumSum(),
+			[]interface{}{nil, 2.0, 3.0},
+		},
+	}
+	for testnum, test := range tests {
+		if err := test.received.Error(); err != nil {
+			t.Errorf("Test:%v\nError:%v", testnum, err)
+		}
+		if !reflect.DeepEqual(test.expected, seriesVals(test.received)) {
+			t.Errorf(
+				"Test:%v\nExpected:\n%v\nReceived:\n%v",
+				testnum, test.expected, seriesVals(test.received),
+			)
+		}
+	}
+
+	ints := Ints(3, 1, 4, 1, 5)
+	received := seriesVals(ints.CumSum())
+	expected := []interface{}{3, 4, 8, 9, 14}
+	if !reflect.DeepEqual(expected, received) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, received)
+	}
+
+	strs := Strings("b", "a", "c")
+	received = seriesVals(strs.CumMax())
+	expected = []interface{}{"b", "b", "c"}
+	if !reflect.DeepEqual(expected, received) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, received)
+	}
+	if err := strs.CumSum().Error(); err == nil {
+		t.Errorf("Expected error for String series on CumSum")
+	}
+	if err := strs.CumProd().Error(); err == nil {
+		t.Errorf("Expected error for String series on CumProd")
+	}
+}
+
+func TestSeries_Rank(t *testing.T) {
+	s := Floats(3.0, 1.0, 4.0, 1.0, math.NaN(), 5.0, 4.0)
+	tests := []struct {
+		method   RankMethod
+		reverse  bool
+		expected []interface{}
+	}{
+		{
+			RankAverage,
+			false,
+			[]interface{}{3.0, 1.5, 4.5, 1.5, nil, 6.0, 4.5},
+		},
+		{
+			RankMin,
+			false,
+			[]interface{}{3.0, 1.0, 4.0, 1.0, nil, 6.0, 4.0},
+		},
+		{
+			RankMax,
+			false,
+			[]interface{}{3.0, 2.0, 5.0, 2.0, nil, 6.0, 5.0},
+		},
+		{
+			RankFirst,
+			false,
+			[]interface{}{3.0, 1.0, 4.0, 2.0, nil, 6.0, 5.0},
+		},
+		{
+			RankDense,
+			false,
+			[]interface{}{2.0, 1.0, 3.0, 1.0, nil, 4.0, 3.0},
+		},
+		{
+			RankAverage,
+			true,
+			[]interface{}{4.0, 5.5, 2.5, 5.5, nil, 1.0, 2.5},
+		},
+		{
+			RankDense,
+			true,
+			[]interface{}{3.0, 4.0, 2.0, 4.0, nil, 1.0, 2.0},
+		},
+	}
+	for testnum, test := range tests {
+		received := s.Rank(test.method, test.reverse)
+		if err := received.Error(); err != nil {
+			t.Errorf("Test:%v\nError:%v", testnum, err)
+		}
+		if !reflect.DeepEqual(test.expected, seriesVals(received)) {
+			t.Errorf(
+				"Test:%v\nExpected:\n%v\nReceived:\n%v",
+				testnum, test.expected, seriesVals(received),
+			)
+		}
+	}
+
+	received := seriesVals(Strings("b", "a", "b").Rank(RankMin, false))
+	expected := []interface{}{2.0, 1.0, 2.0}
+	if !reflect.DeepEqual(expected, received) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, received)
+	}
+}
+
+func TestSeries_ValueCounts(t *testing.T) {
+	tests := []struct {
+		series         Series[string]
+		dropNA         bool
+		expectedValues []interface{}
+		expectedCounts []interface{}
+	}{
+		{
+			Strings("b", "a", "c", "a", "b", "a"),
+			false,
+			[]interface{}{"a", "b", "c"},
+			[]interface{}{3, 2, 1},
+		},
+		{
+			Strings("c", "b", "a"),
+			false,
+			[]interface{}{"c", "b", "a"},
+			[]interface{}{1, 1, 1},
+		},
+	}
+	for testnum, test := range tests {
+		values, counts := test.series.ValueCounts(test.dropNA)
+		if !reflect.DeepEqual(test.expectedValues, seriesVals(values)) {
+			t.Errorf(
+				"Test:%v\nExpected:\n%v\nReceived:\n%v",
+				testnum, test.expectedValues, seriesVals(values),
+			)
+		}
+		if !reflect.DeepEqual(test.expectedCounts, seriesVals(counts)) {
+			t.Errorf(
+				"Test:%v\nExpected:\n%v\nReceived:\n%v",
+				testnum, test.expectedCounts, seriesVals(counts),
+			)
+		}
+	}
+
+	s := Floats(1.0, math.NaN(), 2.0, math.NaN(), 1.0, math.NaN())
+	values, counts := s.ValueCounts()
+	expectedValues := []interface{}{nil, 1.0, 2.0}
+	expectedCounts := []interface{}{3, 2, 1}
+	if !reflect.DeepEqual(expectedValues, seriesVals(values)) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expectedValues, seriesVals(values))
+	}
+	if !reflect.DeepEqual(expectedCounts, seriesVals(counts)) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expectedCounts, seriesVals(counts))
+	}
+
+	values, counts = s.ValueCounts(true)
+	expectedValues = []interface{}{1.0, 2.0}
+	expectedCounts = []interface{}{2, 1}
+	if !reflect.DeepEqual(expectedValues, seriesVals(values)) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expectedValues, seriesVals(values))
+	}
+	if !reflect.DeepEqual(expectedCounts, seriesVals(counts)) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expectedCounts, seriesVals(counts))
+	}
+}
+
+func TestSeries_Unique(t *testing.T) {
+	ints := Ints(3, 1, 3, 2, 1, 3)
+	expected := []interface{}{3, 1, 2}
+	if received := seriesVals(ints.Unique()); !reflect.DeepEqual(expected, received) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, received)
+	}
+	if received := ints.NUnique(false); received != 3 {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", 3, received)
+	}
+
+	strs := Strings("a", "", "b", "a", "")
+	strs.Values().Values()[3] = NewNAElement[string]()
+	expected = []interface{}{"a", "", "b", nil}
+	if received := seriesVals(strs.Unique()); !reflect.DeepEqual(expected, received) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, received)
+	}
+	if received := strs.NUnique(false); received != 4 {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", 4, received)
+	}
+	if received := strs.NUnique(true); received != 3 {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", 3, received)
+	}
+}
+
+func TestSeries_SubsetBoolIndexes(t *testing.T) {
+	s := Strings("a", "b", "c", "d")
+	bools := []bool{true, false, true, true}
+	expected := []interface{}{"a", "c", "d"}
+
+	received := s.Subset(bools)
+	if err := received.Error(); err != nil {
+		t.Errorf("Error:%v", err)
+	}
+	if !reflect.DeepEqual(expected, seriesVals(received)) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, seriesVals(received))
+	}
+
+	received = s.Subset(Bools(bools...))
+	if err := received.Error(); err != nil {
+		t.Errorf("Error:%v", err)
+	}
+	if !reflect.DeepEqual(expected, seriesVals(received)) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, seriesVals(received))
+	}
+
+	received = s.Copy().Subset(Bools(true, false))
+	if received.Error() == nil {
+		t.Errorf("Expected error due to index dimensions mismatch")
+	}
+}
+
+func TestSeries_Apply(t *testing.T) {
+	ints := Ints(1, 5, 10)
+	labels := Apply(ints, func(e Element[int]) string {
+		if e.Val() < 5 {
+			return "low"
+		}
+		return "high"
+	}, "labels")
+	if err := labels.Error(); err != nil {
+		t.Errorf("Error:%v", err)
+	}
+	expected := []interface{}{"low", "high", "high"}
+	if received := seriesVals(labels); !reflect.DeepEqual(expected, received) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, received)
+	}
+	if labels.(*GotaSeries[string]).Name != "labels" {
+		t.Errorf("Expected name labels, got %v", labels.(*GotaSeries[string]).Name)
+	}
+
+	floats := Floats(-1.5, 0, 2.5)
+	positive := ApplyBool(floats, func(e Element[float64]) bool {
+		return e.Val() > 0
+	}, "positive")
+	if err := positive.Error(); err != nil {
+		t.Errorf("Error:%v", err)
+	}
+	expectedBools := []bool{false, false, true}
+	for i, b := range expectedBools {
+		if received := positive.Val(i); received != b {
+			t.Errorf("Index:%v\nExpected:\n%v\nReceived:\n%v", i, b, received)
+		}
+	}
+}
+
+func TestSeries_Clip(t *testing.T) {
+	tests := []struct {
+		series       Series[float64]
+		lower, upper float64
+		expected     []interface{}
+	}{
+		{
+			Floats(-5.0, 0.5, 3.0, 10.0),
+			0.0,
+			5.0,
+			[]interface{}{0.0, 0.5, 3.0, 5.0},
+		},
+		{
+			Floats(1.0, math.NaN(), -2.0),
+			-1.0,
+			0.0,
+			[]interface{}{0.0, nil, -1.0},
+		},
+	}
+	for testnum, test := range tests {
+		received := test.series.Clip(test.lower, test.upper)
+		if err := received.Error(); err != nil {
+			t.Errorf("Test:%v\nError:%v", testnum, err)
+		}
+		if !reflect.DeepEqual(test.expected, seriesVals(received)) {
+			t.Errorf(
+				"Test:%v\nExpected:\n%v\nReceived:\n%v",
+				testnum, test.expected, seriesVals(received),
+			)
+		}
+	}
+
+	if err := Floats(1.0).Clip(1.0, 0.0).Error(); err == nil {
+		t.Errorf("Expected error due to lower bound greater than upper bound")
+	}
+}
+
+func TestSeries_Cut(t *testing.T) {
+	s := Floats(0.0, 1.0, 2.5, 5.0, 7.0, math.NaN(), 12.0, -1.0)
+	tests := []struct {
+		bins     []float64
+		labels   []string
+		expected []interface{}
+	}{
+		{
+			[]float64{0, 2.5, 5, 10},
+			[]string{"low", "mid", "high"},
+			[]interface{}{"low", "low", "low", "mid", "high", nil, nil, nil},
+		},
+		{
+			[]float64{0, 5, 10},
+			nil,
+			[]interface{}{"[0, 5]", "[0, 5]", "[0, 5]", "[0, 5]", "(5, 10]", nil, nil, nil},
+		},
+	}
+	for testnum, test := range tests {
+		received := s.Cut(test.bins, test.labels)
+		if err := received.Error(); err != nil {
+			t.Errorf("Test:%v\nError:%v", testnum, err)
+		}
+		if !reflect.DeepEqual(test.expected, seriesVals(received)) {
+			t.Errorf(
+				"Test:%v\nExpected:\n%v\nReceived:\n%v",
+				testnum, test.expected, seriesVals(received),
+			)
+		}
+	}
+
+	if err := s.Cut([]float64{0, 5, 10}, []string{"a"}).Error(); err == nil {
+		t.Errorf("Expected error due to wrong number of labels")
+	}
+	if err := s.Cut([]float64{5, 0}, nil).Error(); err == nil {
+		t.Errorf("Expected error due to decreasing bin edges")
+	}
+	if err := Strings("a").Cut([]float64{0, 1}, nil).Error(); err == nil {
+		t.Errorf("Expected error due to non numeric series")
+	}
+}
+
+func TestSeries_QCut(t *testing.T) {
+	s := Ints(1, 2, 3, 4, 5, 6, 7, 8)
+	received := s.QCut(4)
+	if err := received.Error(); err != nil {
+		t.Errorf("Error:%v", err)
+	}
+	expected := []interface{}{
+		"[1, 2.75]", "[1, 2.75]", "(2.75, 4.5]", "(2.75, 4.5]",
+		"(4.5, 6.25]", "(4.5, 6.25]", "(6.25, 8]", "(6.25, 8]",
+	}
+	if !reflect.DeepEqual(expected, seriesVals(received)) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, seriesVals(received))
+	}
+
+	// As many quantiles as distinct values
+	received = Ints(1, 2, 3, 4).QCut(4)
+	if err := received.Error(); err != nil {
+		t.Errorf("Error:%v", err)
+	}
+	expected = []interface{}{"[1, 1.75]", "(1.75, 2.5]", "(2.5, 3.25]", "(3.25, 4]"}
+	if !reflect.DeepEqual(expected, seriesVals(received)) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, seriesVals(received))
+	}
+
+	if err := Ints(1, 1, 1, 2).QCut(4).Error(); err == nil {
+		t.Errorf("Expected error due to repeated bin edges")
+	}
+}
+
+func TestSeries_Describe(t *testing.T) {
+	tests := []struct {
+		describe           func() (Series[string], Series[string])
+		expectedStatistics []interface{}
+		expectedValues     []interface{}
+	}{
+		{
+			Floats(4.0, 1.0, math.NaN(), 3.0, 2.0).Describe,
+			[]interface{}{"count", "mean", "std", "min", "25%", "50%", "75%", "max"},
+			[]interface{}{"4", "2.5", strconv.FormatFloat(math.Sqrt(5.0/3.0), 'f', -1, 64), "1", "1", "2", "3", "4"},
+		},
+		{
+			Strings("b", "a", "b", "c", "b").Describe,
+			[]interface{}{"count", "unique", "top", "freq"},
+			[]interface{}{"5", "3", "b", "3"},
+		},
+	}
+	for testnum, test := range tests {
+		statistics, values := test.describe()
+		if !reflect.DeepEqual(test.expectedStatistics, seriesVals(statistics)) {
+			t.Errorf(
+				"Test:%v\nExpected:\n%v\nReceived:\n%v",
+				testnum, test.expectedStatistics, seriesVals(statistics),
+			)
+		}
+		if !reflect.DeepEqual(test.expectedValues, seriesVals(values)) {
+			t.Errorf(
+				"Test:%v\nExpected:\n%v\nReceived:\n%v",
+				testnum, test.expectedValues, seriesVals(values),
+			)
+		}
+	}
+}
+
+func TestSeries_WeightedMean(t *testing.T) {
+	tests := []struct {
+		series   Series[float64]
+		weights  Series[float64]
+		expected float64
+		err      bool
+	}{
+		{
+			Floats(10, 20, 30),
+			Floats(1, 2, 3),
+			(10*1 + 20*2 + 30*3) / 6.0,
+			false,
+		},
+		{
+			Floats(10, math.NaN(), 30, 40),
+			Floats(1, 2, 3, math.NaN()),
+			(10*1 + 30*3) / 4.0,
+			false,
+		},
+		{
+			Floats(10, 20, 30),
+			Floats(1, 2),
+			math.NaN(),
+			true,
+		},
+		{
+			Floats(10, 20),
+			Floats(0, 0),
+			math.NaN(),
+			true,
+		},
+	}
+	for testnum, test := range tests {
+		received, err := test.series.WeightedMean(test.weights)
+		if test.err != (err != nil) {
+			t.Errorf("Test:%v\nUnexpected error value: %v", testnum, err)
+		}
+		if !compareFloats(test.expected, received, 6) {
+			t.Errorf(
+				"Test:%v\nExpected:\n%v\nReceived:\n%v",
+				testnum, test.expected, received,
+			)
+		}
+	}
+}
+
+func TestSeries_WeightedMeanInt(t *testing.T) {
+	received, err := Ints(1, 2, 3).WeightedMean(Floats(0.5, 0.25, 0.25))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := 1.75; !compareFloats(expected, received, 6) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, received)
+	}
+}
+
+func TestSeries_Mode(t *testing.T) {
+	tests := []struct {
+		series   Series[string]
+		expected []interface{}
+	}{
+		{
+			Strings("a", "b", "b", "c", "b"),
+			[]interface{}{"b"},
+		},
+		{
+			Strings("c", "a", "a", "b", "c", "b", "d"),
+			[]interface{}{"c", "a", "b"},
+		},
+		{
+			Strings("c", "b", "a"),
+			[]interface{}{"c", "b", "a"},
+		},
+	}
+	for testnum, test := range tests {
+		received := seriesVals(test.series.Mode())
+		if !reflect.DeepEqual(test.expected, received) {
+			t.Errorf(
+				"Test:%v\nExpected:\n%v\nReceived:\n%v",
+				testnum, test.expected, received,
+			)
+		}
+	}
+
+	received := seriesVals(Floats(math.NaN(), 2, math.NaN(), 1, 2, math.NaN()).Mode())
+	if expected := []interface{}{2.0}; !reflect.DeepEqual(expected, received) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, received)
+	}
+}
+
+func TestSeries_Moments(t *testing.T) {
+	sample := []float64{2, 8, 0, 4, 1, 9, 9, 0, 3.5, 6}
+	withNaN := Floats(append([]float64{math.NaN()}, sample...)...)
+	intsWithNaN := Ints(1, 2, 3, 4)
+	intsWithNaN.AppendNA()
+	tests := []struct {
+		moment   func() float64
+		expected float64
+	}{
+		{Floats(sample...).Var, stat.Variance(sample, nil)},
+		{Floats(sample...).Skewness, stat.Skew(sample, nil)},
+		{Floats(sample...).Kurtosis, stat.ExKurtosis(sample, nil)},
+		{Ints(1, 2, 3, 4).Var, 5.0 / 3.0},
+		{Floats(1).Var, math.NaN()},
+		{Floats(1, 2).Skewness, math.NaN()},
+		{Floats(1, 2, 3).Kurtosis, math.NaN()},
+		{withNaN.Var, stat.Variance(sample, nil)},
+		{withNaN.Skewness, stat.Skew(sample, nil)},
+		{withNaN.Kurtosis, stat.ExKurtosis(sample, nil)},
+		{intsWithNaN.Var, 5.0 / 3.0},
+		{Floats(math.NaN(), math.NaN()).Var, math.NaN()},
+		{Floats(1, 2, math.NaN()).Skewness, math.NaN()},
+		{Floats(1, 2, 3, math.NaN()).Kurtosis, math.NaN()},
+		{Strings("a", "b", "c", "d").Var, math.NaN()},
+		{Strings("a", "b", "c", "d").Skewness, math.NaN()},
+		{Strings("a", "b", "c", "d").Kurtosis, math.NaN()},
+	}
+	for testnum, test := range tests {
+		received := test.moment()
+		if !compareFloats(test.expected, received, 6) {
+			t.Errorf(
+				"Test:%v\nExpected:\n%v\nReceived:\n%v",
+				testnum, test.expected, received,
+			)
+		}
+	}
+}
+
+func TestSeries_MinMaxScale(t *testing.T) {
+	tests := []struct {
+		series   Series[float64]
+		expected []interface{}
+	}{
+		{
+			Floats(2, 4, math.NaN(), 10, 6),
+			[]interface{}{0.0, 0.25, nil, 1.0, 0.5},
+		},
+		{
+			Floats(3, 3, math.NaN()),
+			[]interface{}{0.0, 0.0, nil},
+		},
+	}
+	for testnum, test := range tests {
+		received := seriesVals(test.series.MinMaxScale())
+		if !reflect.DeepEqual(test.expected, received) {
+			t.Errorf(
+				"Test:%v\nExpected:\n%v\nReceived:\n%v",
+				testnum, test.expected, received,
+			)
+		}
+	}
+}
+
+func TestSeries_ZScore(t *testing.T) {
+	received := Ints(1, 2, 3, 4, 5).ZScore()
+	std := math.Sqrt(2.5)
+	expected := []float64{-2 / std, -1 / std, 0, 1 / std, 2 / std}
+	for i, e := range expected {
+		if !compareFloats(e, received.Val(i), 6) {
+			t.Errorf("Index:%v\nExpected:\n%v\nReceived:\n%v", i, e, received.Val(i))
+		}
+	}
+	if mean := received.Mean(); !compareFloats(0, mean, 6) {
+		t.Errorf("Expected mean 0, received %v", mean)
+	}
+
+	withNaN := seriesVals(Floats(1, math.NaN(), 3).ZScore())
+	expectedNaN := []interface{}{-1 / math.Sqrt(2), nil, 1 / math.Sqrt(2)}
+	if !reflect.DeepEqual(expectedNaN, withNaN) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expectedNaN, withNaN)
+	}
+
+	constant := seriesVals(Floats(7, 7, math.NaN(), 7).ZScore())
+	if expected := []interface{}{0.0, 0.0, nil, 0.0}; !reflect.DeepEqual(expected, constant) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, constant)
+	}
+}
+
+func TestSeries_ElemSafe(t *testing.T) {
+	s := Ints(10, 20, 30)
+	for i, expected := range []int{10, 20, 30} {
+		e, err := s.ElemSafe(i)
+		if err != nil {
+			t.Errorf("Index:%v\nUnexpected error: %v", i, err)
+			continue
+		}
+		if e.Val() != expected {
+			t.Errorf("Index:%v\nExpected:\n%v\nReceived:\n%v", i, expected, e.Val())
+		}
+	}
+	for _, i := range []int{-1, 3} {
+		if _, err := s.ElemSafe(i); err == nil {
+			t.Errorf("Index:%v\nExpected error", i)
+		}
+	}
+}
+
+func TestSeries_All(t *testing.T) {
+	s := Ints(10, 20, 30, 40)
+
+	var received []int
+	for i, e := range s.All() {
+		if e.Val() != s.Val(i) {
+			t.Errorf("Index:%v\nExpected:\n%v\nReceived:\n%v", i, s.Val(i), e.Val())
+		}
+		received = append(received, e.Val())
+	}
+	if expected := []int{10, 20, 30, 40}; !reflect.DeepEqual(expected, received) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, received)
+	}
+
+	received = nil
+	for _, e := range s.All() {
+		if e.Val() > 20 {
+			break
+		}
+		received = append(received, e.Val())
+	}
+	if expected := []int{10, 20}; !reflect.DeepEqual(expected, received) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, received)
+	}
+
+	for range Ints().All() {
+		t.Errorf("Expected no elements on an empty Series")
+	}
+}
+
+func TestSeries_Reverse(t *testing.T) {
+	s := NewSeries("A", 1, 2, 3, 4)
+	received := s.Reverse()
+
+	if expected := []interface{}{4, 3, 2, 1}; !reflect.DeepEqual(expected, seriesVals(received)) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, seriesVals(received))
+	}
+	if received.(*GotaSeries[int]).Name != "A" {
+		t.Errorf("Expected name A, received %v", received.(*GotaSeries[int]).Name)
+	}
+	if expected := []interface{}{1, 2, 3, 4}; !reflect.DeepEqual(expected, seriesVals(s)) {
+		t.Errorf("Original Series modified:\nExpected:\n%v\nReceived:\n%v", expected, seriesVals(s))
+	}
+
+	withNaN := seriesVals(Floats(1, math.NaN(), 3).Reverse())
+	if expected := []interface{}{3.0, nil, 1.0}; !reflect.DeepEqual(expected, withNaN) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, withNaN)
+	}
+	if received := Ints().Reverse(); received.Len() != 0 {
+		t.Errorf("Expected empty Series, received %v", received)
+	}
+}
+
+func TestSeries_ConcatCopy(t *testing.T) {
+	a := NewSeries("A", 1, 2)
+	b := NewSeries("B", 3, 4)
+	ab := a.Concat(b)
+	if err := ab.Error(); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+
+	a.Elem(0).Set(10)
+	b.Elem(1).Set(40)
+	b.Append(5)
+	if expected := []interface{}{1, 2, 3, 4}; !reflect.DeepEqual(expected, seriesVals(ab)) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, seriesVals(ab))
+	}
+	if name := ab.(*GotaSeries[int]).Name; name != "A" {
+		t.Errorf("Expected name A, received %v", name)
+	}
+
+	ab.Elem(2).Set(30)
+	if expected := []interface{}{3, 40, 5}; !reflect.DeepEqual(expected, seriesVals(b)) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, seriesVals(b))
+	}
+
+	if received := Ints().Concat(Ints(1, 2)); !reflect.DeepEqual([]interface{}{1, 2}, seriesVals(received)) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", []interface{}{1, 2}, seriesVals(received))
+	}
+	if received := Ints(1, 2).Concat(Ints()); !reflect.DeepEqual([]interface{}{1, 2}, seriesVals(received)) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", []interface{}{1, 2}, seriesVals(received))
+	}
+
+	failed := &GotaSeries[int]{Name: "F", elements: NewElements[int](), Err: fmt.Errorf("failed")}
+	c := NewSeries("C", 1)
+	if err := c.Concat(failed).Error(); err == nil {
+		t.Errorf("Expected error due to argument with errors")
+	}
+	if err := c.Error(); err != nil {
+		t.Errorf("Expected receiver to be left unchanged, got error: %v", err)
+	}
+}
+
+func TestCategorical(t *testing.T) {
+	levels := []string{"low", "medium", "high"}
+	c := NewCategorical([]string{"high", "low", "unknown", "medium", "low"}, levels)
+	if err := c.Error(); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+
+	if expected := []int{2, 0, -1, 1, 0}; !reflect.DeepEqual(expected, c.Codes()) {
+		t.Errorf("Codes:\nExpected:\n%v\nReceived:\n%v", expected, c.Codes())
+	}
+	if expected := []string{"high", "low", "NaN", "medium", "low"}; !reflect.DeepEqual(expected, c.Records()) {
+		t.Errorf("Records:\nExpected:\n%v\nReceived:\n%v", expected, c.Records())
+	}
+	if expected := []interface{}{"high", "low", nil, "medium", "low"}; !reflect.DeepEqual(expected, seriesVals(c.Strings())) {
+		t.Errorf("Strings:\nExpected:\n%v\nReceived:\n%v", expected, seriesVals(c.Strings()))
+	}
+
+	// "medium" sorts before "high" despite the lexicographical order
+	if expected := []int{1, 4, 3, 0, 2}; !reflect.DeepEqual(expected, c.Order(false)) {
+		t.Errorf("Order:\nExpected:\n%v\nReceived:\n%v", expected, c.Order(false))
+	}
+	if expected := []int{0, 3, 1, 4, 2}; !reflect.DeepEqual(expected, c.Order(true)) {
+		t.Errorf("Reverse order:\nExpected:\n%v\nReceived:\n%v", expected, c.Order(true))
+	}
+
+	compareTests := []struct {
+		comparator Comparator
+		expected   []bool
+	}{
+		{Greater, []bool{true, false, false, false, false}},
+		{GreaterEq, []bool{true, false, false, true, false}},
+		{Less, []bool{false, true, false, false, true}},
+		{Neq, []bool{true, true, false, false, true}},
+	}
+	for testnum, test := range compareTests {
+		received, err := c.Compare(test.comparator, "medium")
+		if err != nil {
+			t.Errorf("Test:%v\nError:%v", testnum, err)
+		}
+		if !reflect.DeepEqual(test.expected, received) {
+			t.Errorf(
+				"Test:%v\nExpected:\n%v\nReceived:\n%v",
+				testnum, test.expected, received,
+			)
+		}
+	}
+	if _, err := c.Compare(Eq, "extreme"); err == nil {
+		t.Errorf("Expected error due to unknown level")
+	}
+
+	expectedGroups := map[string][]int{"low": {1, 4}, "medium": {3}, "high": {0}}
+	if !reflect.DeepEqual(expectedGroups, c.Groups()) {
+		t.Errorf("Groups:\nExpected:\n%v\nReceived:\n%v", expectedGroups, c.Groups())
+	}
+
+	if err := NewCategorical([]string{"a"}, []string{"a", "a"}).Error(); err == nil {
+		t.Errorf("Expected error due to duplicated levels")
+	}
+}
+
+func TestSeries_Uints(t *testing.T) {
+	values := []uint{0, 1, math.MaxUint64}
+	s := Uints(values...)
+	if err := s.Error(); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	if s.Len() != len(values) {
+		t.Errorf("Expected length %d, received %d", len(values), s.Len())
+	}
+	for i, v := range values {
+		if s.Val(i) != v {
+			t.Errorf("Index:%v\nExpected:\n%v\nReceived:\n%v", i, v, s.Val(i))
+		}
+		parsed, err := strconv.ParseUint(strconv.FormatUint(uint64(s.Val(i)), 10), 10, 0)
+		if err != nil || uint(parsed) != v {
+			t.Errorf("Index:%v\nRound trip failed: %v, %v", i, parsed, err)
+		}
+	}
+	if expected := []interface{}{uint(0), uint(1), uint(math.MaxUint64)}; !reflect.DeepEqual(expected, seriesVals(s)) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, seriesVals(s))
+	}
+}
+
+func TestSeries_Times(t *testing.T) {
+	s := ParseTimes(time.RFC3339,
+		"2021-03-01T00:00:00Z",
+		"2020-12-31T10:30:00Z",
+		"NaN",
+		"2021-01-15T00:00:00Z",
+	)
+	if err := s.Error(); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	expected := []string{"2021-03-01T00:00:00Z", "2020-12-31T10:30:00Z", "NaN", "2021-01-15T00:00:00Z"}
+	if received := TimeRecords(s); !reflect.DeepEqual(expected, received) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, received)
+	}
+	if expected := []int{1, 3, 0, 2}; !reflect.DeepEqual(expected, s.Order(false)) {
+		t.Errorf("Expected order:\n%v\nReceived:\n%v", expected, s.Order(false))
+	}
+
+	low := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	high := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	between := s.Between(low.UnixNano(), high.UnixNano(), true)
+	received := make([]bool, between.Len())
+	for i := range received {
+		received[i] = between.Val(i)
+	}
+	if expected := []bool{true, false, false, true}; !reflect.DeepEqual(expected, received) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, received)
+	}
+
+	u := Times(high, time.Time{})
+	if tm, ok := ElementTime(u.Elem(0)); !ok || !tm.Equal(high) {
+		t.Errorf("Expected %v, received %v, %v", high, tm, ok)
+	}
+	if !u.Elem(1).IsNA() {
+		t.Errorf("Expected the zero time to be NaN")
+	}
+}
+
+func TestFlatElements(t *testing.T) {
+	s := Floats(1, math.NaN(), 3)
+	if _, ok := s.Values().(*FlatElements[float64]); !ok {
+		t.Errorf("Expected Float Series to be stored as FlatElements, got %T", s.Values())
+	}
+	if _, ok := Strings("a").Values().(*FlatElements[string]); ok {
+		t.Errorf("Expected String Series not to be stored as FlatElements")
+	}
+
+	if expected := []interface{}{1.0, nil, 3.0}; !reflect.DeepEqual(expected, seriesVals(s)) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, seriesVals(s))
+	}
+	floats := s.(*GotaSeries[float64]).Float()
+	if floats[0] != 1 || !math.IsNaN(floats[1]) || floats[2] != 3 {
+		t.Errorf("Unexpected Float values: %v", floats)
+	}
+
+	// Setting an element writes through to the underlying values, while copies
+	// are detached from them.
+	c := s.Elem(0).Copy()
+	s.Elem(0).Set(10)
+	s.Elem(1).Set(2)
+	if expected := []interface{}{10.0, 2.0, 3.0}; !reflect.DeepEqual(expected, seriesVals(s)) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, seriesVals(s))
+	}
+	if c.Val() != 1 {
+		t.Errorf("Expected copy to keep value 1, got %v", c.Val())
+	}
+	if sum := s.Sum(); sum != 15 {
+		t.Errorf("Expected sum 15, got %v", sum)
+	}
+
+	// Appending crosses the bitset word boundary
+	values := make([]int, 70)
+	for i := range values {
+		values[i] = i
+	}
+	a := NewFlatElements(values[:40]...)
+	a.AppendElements(NewFlatElements(values[40:]...))
+	a.AppendElements(&ElementsArray[int]{2, []Element[int]{NewElement(70), NewNAElement[int]()}})
+	if a.Len() != 72 {
+		t.Fatalf("Expected length 72, got %d", a.Len())
+	}
+	for i := 0; i < 71; i++ {
+		if a.Elem(i).IsNA() || a.Elem(i).Val() != i {
+			t.Errorf("Index:%v\nUnexpected element %v (NaN: %v)", i, a.Elem(i).Val(), a.Elem(i).IsNA())
+		}
+	}
+	if !a.Elem(71).IsNA() {
+		t.Errorf("Expected last element to be NaN")
+	}
+}
+
+func BenchmarkSeries_SumFloats(b *testing.B) {
+	data := make([]float64, 1000000)
+	elements := make([]Element[float64], len(data))
+	for i := range data {
+		data[i] = float64(i)
+		elements[i] = NewElement(data[i])
+	}
+	table := []struct {
+		name   string
+		series Series[float64]
+	}{
+		{
+			"ElementsArray(1000000)",
+			&GotaSeries[float64]{elements: &ElementsArray[float64]{len(elements), elements}},
+		},
+		{
+			"FlatElements(1000000)",
+			&GotaSeries[float64]{elements: NewFlatElements(data...)},
+		},
+	}
+	for _, test := range table {
+		b.Run(test.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				test.series.Sum()
+			}
+		})
+	}
+}
+
+func TestFlatElements_FloatNaN(t *testing.T) {
+	fe := NewFlatElements(1.0, 2.0)
+	fe.AppendElements(&ElementsArray[float64]{1, []Element[float64]{NewNAElement[float64]()}})
+	floats := (&GotaSeries[float64]{elements: fe}).Float()
+	if floats[0] != 1 || floats[1] != 2 || !math.IsNaN(floats[2]) {
+		t.Errorf("Unexpected Float values: %v", floats)
+	}
+}
+
+func TestSeries_Grow(t *testing.T) {
+	tests := []Series[int]{
+		Ints(1, 2),
+		&GotaSeries[int]{elements: &ElementsArray[int]{2, []Element[int]{NewElement(1), NewElement(2)}}},
+	}
+	for testnum, s := range tests {
+		s.Grow(100)
+		s.Grow(-1)
+		s.Append(3, 4)
+		if expected := []interface{}{1, 2, 3, 4}; !reflect.DeepEqual(expected, seriesVals(s)) {
+			t.Errorf(
+				"Test:%v\nExpected:\n%v\nReceived:\n%v",
+				testnum, expected, seriesVals(s),
+			)
+		}
+	}
+
+	fe := NewFlatElements(1.0)
+	fe.Grow(200)
+	if cap(fe.values) < 201 || cap(fe.nan)*64 < 201 {
+		t.Errorf("Expected capacity for 201 elements, got %d values and %d NaN words", cap(fe.values), cap(fe.nan))
+	}
+}
+
+func TestSeries_AppendNA(t *testing.T) {
+	ints := Ints(1, 2)
+	ints.AppendNA()
+	ints.Append(3)
+	if expected := []interface{}{1, 2, nil, 3}; !reflect.DeepEqual(expected, seriesVals(ints)) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, seriesVals(ints))
+	}
+
+	floats := Floats(1.5)
+	floats.AppendNA()
+	if expected := []bool{false, true}; !reflect.DeepEqual(expected, floats.IsNaN()) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, floats.IsNaN())
+	}
+
+	strs := Strings("a")
+	strs.AppendNA()
+	if expected := []interface{}{"a", nil}; !reflect.DeepEqual(expected, seriesVals(strs)) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, seriesVals(strs))
+	}
+
+	failed := &GotaSeries[int]{Name: "F", elements: NewElements(1), Err: fmt.Errorf("failed")}
+	failed.AppendNA()
+	if failed.Len() != 1 {
+		t.Errorf("Expected series with errors to be left unchanged, got length %d", failed.Len())
+	}
+}
+
+func TestSeries_FillNA(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		series   Series[float64]
+		forward  []interface{}
+		backward []interface{}
+	}{
+		{
+			Floats(nan, 1, nan, nan, 4, nan),
+			[]interface{}{nil, 1.0, 1.0, 1.0, 4.0, 4.0},
+			[]interface{}{1.0, 1.0, 4.0, 4.0, 4.0, nil},
+		},
+		{
+			Floats(1, 2, 3),
+			[]interface{}{1.0, 2.0, 3.0},
+			[]interface{}{1.0, 2.0, 3.0},
+		},
+		{
+			Floats(nan, nan),
+			[]interface{}{nil, nil},
+			[]interface{}{nil, nil},
+		},
+		{
+			Floats(),
+			[]interface{}{},
+			[]interface{}{},
+		},
+	}
+	for testnum, test := range tests {
+		forward := test.series.FillNAForward()
+		if err := forward.Error(); err != nil {
+			t.Errorf("Test:%v\nError:%v", testnum, err)
+		}
+		if received := seriesVals(forward); !reflect.DeepEqual(test.forward, received) {
+			t.Errorf(
+				"Test:%v\nExpected forward:\n%v\nReceived:\n%v",
+				testnum, test.forward, received,
+			)
+		}
+		backward := test.series.FillNABackward()
+		if err := backward.Error(); err != nil {
+			t.Errorf("Test:%v\nError:%v", testnum, err)
+		}
+		if received := seriesVals(backward); !reflect.DeepEqual(test.backward, received) {
+			t.Errorf(
+				"Test:%v\nExpected backward:\n%v\nReceived:\n%v",
+				testnum, test.backward, received,
+			)
+		}
+	}
+
+	s := Floats(1, nan)
+	s.FillNAForward().Elem(1).Set(10)
+	if !s.Elem(1).IsNA() {
+		t.Errorf("Expected FillNAForward to return a copy of the Series")
+	}
+}
+
+func TestSeries_Interpolate(t *testing.T) {
+	nan := math.NaN()
+	ints := Ints(10)
+	ints.AppendNA()
+	ints.Append(20)
+	tests := []struct {
+		series   Series[float64]
+		expected []interface{}
+	}{
+		{
+			Floats(nan, 1, nan, nan, 4, nan, nan),
+			[]interface{}{nil, 1.0, 2.0, 3.0, 4.0, nil, nil},
+		},
+		{
+			Floats(0, nan, 1),
+			[]interface{}{0.0, 0.5, 1.0},
+		},
+		{
+			Floats(nan, nan),
+			[]interface{}{nil, nil},
+		},
+		{
+			ints.Interpolate(),
+			[]interface{}{10.0, 15.0, 20.0},
+		},
+	}
+	for testnum, test := range tests {
+		received := test.series.Interpolate()
+		if err := received.Error(); err != nil {
+			t.Errorf("Test:%v\nError:%v", testnum, err)
+		}
+		if vals := seriesVals(received); !reflect.DeepEqual(test.expected, vals) {
+			t.Errorf(
+				"Test:%v\nExpected:\n%v\nReceived:\n%v",
+				testnum, test.expected, vals,
+			)
+		}
+	}
+
+	if err := Strings("a", "b").Interpolate().Error(); err == nil {
+		t.Errorf("Expected error for String series")
+	}
+}
+
+func TestSeries_StrOps(t *testing.T) {
+	s := Strings("  Foo bar ", "foo.log", "BAZ", "héllo")
+	s.AppendNA()
+	str := s.StrOps()
+
+	boolTests := []struct {
+		received BoolSeries
+		expected []bool
+	}{
+		{str.Contains("o"), []bool{true, true, false, true, false}},
+		{str.StartsWith("foo"), []bool{false, true, false, false, false}},
+		{str.EndsWith(".log"), []bool{false, true, false, false, false}},
+	}
+	for testnum, test := range boolTests {
+		received := make([]bool, test.received.Len())
+		for i := range received {
+			received[i] = test.received.Val(i)
+		}
+		if !reflect.DeepEqual(test.expected, received) {
+			t.Errorf(
+				"Test:%v\nExpected:\n%v\nReceived:\n%v",
+				testnum, test.expected, received,
+			)
+		}
+	}
+
+	stringTests := []struct {
+		received Series[string]
+		expected []interface{}
+	}{
+		{str.ToUpper(), []interface{}{"  FOO BAR ", "FOO.LOG", "BAZ", "HÉLLO", nil}},
+		{str.ToLower(), []interface{}{"  foo bar ", "foo.log", "baz", "héllo", nil}},
+		{str.Trim(), []interface{}{"Foo bar", "foo.log", "BAZ", "héllo", nil}},
+	}
+	for testnum, test := range stringTests {
+		if err := test.received.Error(); err != nil {
+			t.Errorf("Test:%v\nError:%v", testnum, err)
+		}
+		if received := seriesVals(test.received); !reflect.DeepEqual(test.expected, received) {
+			t.Errorf(
+				"Test:%v\nExpected:\n%v\nReceived:\n%v",
+				testnum, test.expected, received,
+			)
+		}
+	}
+
+	if expected, received := []interface{}{10, 7, 3, 5, nil}, seriesVals(str.Len()); !reflect.DeepEqual(expected, received) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, received)
+	}
+
+	ints := Ints(1, 2).StrOps()
+	if err := ints.ToUpper().Error(); err == nil {
+		t.Errorf("Expected error for Int series")
+	}
+	if err := ints.Len().Error(); err == nil {
+		t.Errorf("Expected error for Int series")
+	}
+}
+
+func TestSeries_StrOpsRegex(t *testing.T) {
+	s := Strings("report_2021.csv", "report_1999_final.csv", "notes.txt")
+	s.AppendNA()
+	str := s.StrOps()
+
+	tests := []struct {
+		received Series[string]
+		expected []interface{}
+	}{
+		{str.Extract(`_(\d{4})`, 1), []interface{}{"2021", "1999", nil, nil}},
+		{str.Extract(`\.(\w+)$`, 0), []interface{}{".csv", ".csv", ".txt", nil}},
+		{str.Extract(`(\d+)|(txt)`, 2), []interface{}{nil, nil, "txt", nil}},
+		{str.ReplaceRegex(`\d`, "#"), []interface{}{"report_####.csv", "report_####_final.csv", "notes.txt", nil}},
+		{str.ReplaceRegex(`^(\w+)\.(\w+)$`, "$2:$1"), []interface{}{"csv:report_2021", "csv:report_1999_final", "txt:notes", nil}},
+	}
+	for testnum, test := range tests {
+		if err := test.received.Error(); err != nil {
+			t.Errorf("Test:%v\nError:%v", testnum, err)
+		}
+		if received := seriesVals(test.received); !reflect.DeepEqual(test.expected, received) {
+			t.Errorf(
+				"Test:%v\nExpected:\n%v\nReceived:\n%v",
+				testnum, test.expected, received,
+			)
+		}
+	}
+
+	errTests := []Series[string]{
+		str.Extract(`(`, 0),
+		str.Extract(`(\d)`, 2),
+		str.ReplaceRegex(`[`, ""),
+		Ints(1).StrOps().Extract(`\d`, 0),
+	}
+	for testnum, received := range errTests {
+		if received.Error() == nil {
+			t.Errorf("Test:%v\nExpected error", testnum)
+		}
+	}
+}
+
+func TestSeries_Shuffle(t *testing.T) {
+	s := Ints(1, 2, 3, 4, 5, 6, 7, 8)
+	s.AppendNA()
+
+	a := s.Shuffle(42)
+	if err := a.Error(); err != nil {
+		t.Fatalf("Error:%v", err)
+	}
+	if received := seriesVals(s.Shuffle(42)); !reflect.DeepEqual(seriesVals(a), received) {
+		t.Errorf("Expected the same permutation for the same seed:\n%v\n%v", seriesVals(a), received)
+	}
+	if expected := []interface{}{1, 2, 3, 4, 5, 6, 7, 8, nil}; reflect.DeepEqual(expected, seriesVals(a)) {
+		t.Errorf("Expected the elements to be permuted, got %v", seriesVals(a))
+	}
+
+	perm := rand.New(rand.NewSource(42)).Perm(s.Len())
+	for i, j := range perm {
+		if a.Elem(i).IsNA() != s.Elem(j).IsNA() || (!s.Elem(j).IsNA() && a.Val(i) != s.Val(j)) {
+			t.Errorf("Index:%v\nExpected element %v, got %v", i, s.Elem(j), a.Elem(i))
+		}
+	}
+
+	a.Elem(0).Set(100)
+	for i := 0; i < s.Len(); i++ {
+		if !s.Elem(i).IsNA() && s.Val(i) == 100 {
+			t.Errorf("Expected Shuffle to return a copy of the Series")
+		}
+	}
+}
+
+func TestSeries_Between(t *testing.T) {
+	s := Floats(-1.0, 0.0, 0.5, math.NaN(), 1.0, 2.0)
+	tests := []struct {
+		low, high float64
+		inclusive bool
+		expected  []bool
+	}{
+		{0.0, 1.0, true, []bool{false, true, true, false, true, false}},
+		{0.0, 1.0, false, []bool{false, false, true, false, false, false}},
+		{1.0, 0.0, true, []bool{false, false, false, false, false, false}},
+		{2.0, 2.0, true, []bool{false, false, false, false, false, true}},
+	}
+	for testnum, test := range tests {
+		b := s.Between(test.low, test.high, test.inclusive)
+		if err := b.Error(); err != nil {
+			t.Errorf("Test:%v\nError:%v", testnum, err)
+			continue
+		}
+		received := make([]bool, b.Len())
+		for i := range received {
+			received[i] = b.Val(i)
+		}
+		if !reflect.DeepEqual(test.expected, received) {
+			t.Errorf(
+				"Test:%v\nExpected:\n%v\nReceived:\n%v",
+				testnum, test.expected, received,
+			)
+		}
+	}
+}
+
+func TestFlatElements_Storage(t *testing.T) {
+	// Setting a NaN element behaves the same regardless of the storage
+	tests := []struct {
+		series Series[float64]
+	}{
+		{Floats(1.0, math.NaN())},
+		{&GotaSeries[float64]{elements: &ElementsArray[float64]{2, []Element[float64]{NewElement(1.0), NewNAElement[float64]()}}}},
+	}
+	for testnum, test := range tests {
+		test.series.Elem(1).Set(2)
+		test.series.Elem(0).Set(math.NaN())
+		if expected := []interface{}{nil, 2.0}; !reflect.DeepEqual(expected, seriesVals(test.series)) {
+			t.Errorf(
+				"Test:%v\nExpected:\n%v\nReceived:\n%v",
+				testnum, expected, seriesVals(test.series),
+			)
+		}
+	}
+	str := Strings("a")
+	str.AppendNA()
+	str.Elem(1).Set("b")
+	if expected := []interface{}{"a", "b"}; !reflect.DeepEqual(expected, seriesVals(str)) {
+		t.Errorf("Expected:\n%v\nReceived:\n%v", expected, seriesVals(str))
+	}
+
+	// Accessing the elements doesn't allocate
+	s := Floats(1.0, 2.0, 3.0)
+	if allocs := testing.AllocsPerRun(100, func() { _ = s.Elem(1) }); allocs != 0 {
+		t.Errorf("Expected no allocations accessing an element, got %v", allocs)
+	}
+
+	// Numeric results are stored as FlatElements too
+	ints := Ints(3, 1, 2, 1)
+	results := []Series[int]{
+		ints.Shift(1),
+		ints.Diff(1),
+		ints.CumSum(),
+		ints.FillNAForward(),
+		ints.Unique(),
+		ints.Clip(1, 2),
+		ints.Mode(),
+		ints.Shuffle(1),
+		Strings("a", "bc").StrOps().Len(),
+	}
+	for testnum, received := range results {
+		if _, ok := received.Values().(*FlatElements[int]); !ok {
+			t.Errorf("Test:%v\nExpected FlatElements, got %T", testnum, received.Values())
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package series
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// StrAccessor groups the vectorized string operations of a String Series.
+// Every operation is applied element-wise, and NaN elements are kept as NaN on
+// the resulting Series, or evaluate to false on the resulting BoolSeries.
+type StrAccessor interface {
+	Contains(substr string) BoolSeries
+	StartsWith(prefix string) BoolSeries
+	EndsWith(suffix string) BoolSeries
+	ToUpper() Series[string]
+	ToLower() Series[string]
+	Trim() Series[string]
+	Len() Series[int]
+	Extract(pattern string, group int) Series[string]
+	ReplaceRegex(pattern, repl string) Series[string]
+}
+
+// StrMethods implements StrAccessor for a String Series.
+type StrMethods struct {
+	name   string
+	series Series[string]
+	err    error
+}
+
+// StrOps returns the string operations of the Series. It is only valid for
+// String Series, otherwise every operation of the returned accessor yields an
+// error.
+func (s *GotaSeries[T]) StrOps() StrAccessor {
+	m := StrMethods{
+		name: s.Name,
+		err:  s.Err,
+	}
+	if m.err == nil {
+		if ss, ok := any(s).(*GotaSeries[string]); ok {
+			m.series = ss
+		} else {
+			m.err = fmt.Errorf("str: series is not of type string")
+		}
+	}
+	return m
+}
+
+// Contains reports whether every element contains substr.
+func (m StrMethods) Contains(substr string) BoolSeries {
+	return m.applyBool(func(s string) bool {
+		return strings.Contains(s, substr)
+	})
+}
+
+// StartsWith reports whether every element begins with prefix.
+func (m StrMethods) StartsWith(prefix string) BoolSeries {
+	return m.applyBool(func(s string) bool {
+		return strings.HasPrefix(s, prefix)
+	})
+}
+
+// EndsWith reports whether every element ends with suffix.
+func (m StrMethods) EndsWith(suffix string) BoolSeries {
+	return m.applyBool(func(s string) bool {
+		return strings.HasSuffix(s, suffix)
+	})
+}
+
+// ToUpper returns the elements mapped to upper case.
+func (m StrMethods) ToUpper() Series[string] {
+	return strApply(m, strings.ToUpper)
+}
+
+// ToLower returns the elements mapped to lower case.
+func (m StrMethods) ToLower() Series[string] {
+	return strApply(m, strings.ToLower)
+}
+
+// Trim returns the elements with their leading and trailing white space
+// removed.
+func (m StrMethods) Trim() Series[string] {
+	return strApply(m, strings.TrimSpace)
+}
+
+// Len returns the number of characters of every element.
+func (m StrMethods) Len() Series[int] {
+	return strApply(m, utf8.RuneCountInString)
+}
+
+// Extract returns the text matched by the given capture group of the regular
+// expression on every element, where group 0 is the whole match. Elements not
+// matching the pattern result in NaN.
+func (m StrMethods) Extract(pattern string, group int) Series[string] {
+	if m.err != nil {
+		return newErrorSeries[string](m.name, m.err)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return newErrorSeries[string](m.name, fmt.Errorf("extract: %v", err))
+	}
+	if group < 0 || group > re.NumSubexp() {
+		return newErrorSeries[string](m.name, fmt.Errorf("extract: group %d out of range for pattern %q", group, pattern))
+	}
+
+	elements := make([]Element[string], m.series.Len())
+	for i := range elements {
+		e := m.series.Elem(i)
+		var match []int
+		if !e.IsNA() {
+			match = re.FindStringSubmatchIndex(e.Val())
+		}
+		if match == nil || match[2*group] < 0 {
+			elements[i] = NewNAElement[string]()
+			continue
+		}
+		elements[i] = NewElement(e.Val()[match[2*group]:match[2*group+1]])
+	}
+
+	ret := GotaSeries[string]{
+		Name:     m.name,
+		elements: &ElementsArray[string]{len(elements), elements},
+	}
+	return &ret
+}
+
+// ReplaceRegex returns the elements with every match of the regular expression
+// replaced by repl, which can refer to capture groups as in
+// regexp.Regexp.ReplaceAllString.
+func (m StrMethods) ReplaceRegex(pattern, repl string) Series[string] {
+	if m.err != nil {
+		return newErrorSeries[string](m.name, m.err)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return newErrorSeries[string](m.name, fmt.Errorf("replace regex: %v", err))
+	}
+	return strApply(m, func(s string) string {
+		return re.ReplaceAllString(s, repl)
+	})
+}
+
+func (m StrMethods) applyBool(f func(string) bool) BoolSeries {
+	if m.err != nil {
+		return &GotaBoolSeries{Name: m.name, Err: m.err}
+	}
+	values := make([]bool, m.series.Len())
+	for i := range values {
+		e := m.series.Elem(i)
+		values[i] = !e.IsNA() && f(e.Val())
+	}
+	return NewBoolSeries(m.name, values...)
+}
+
+// strApply returns a Series with the results of applying f to every non NaN
+// element of the String Series of the accessor.
+func strApply[U SeriesType](m StrMethods, f func(string) U) Series[U] {
+	if m.err != nil {
+		return newErrorSeries[U](m.name, m.err)
+	}
+	elements := make([]Element[U], m.series.Len())
+	for i := range elements {
+		e := m.series.Elem(i)
+		if e.IsNA() {
+			elements[i] = NewNAElement[U]()
+			continue
+		}
+		elements[i] = NewElement(f(e.Val()))
+	}
+
+	ret := GotaSeries[U]{
+		Name:     m.name,
+		elements: elementsOf(elements),
+	}
+	return &ret
+}
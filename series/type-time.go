@@ -0,0 +1,76 @@
+package series
+
+import (
+	"time"
+)
+
+// Time is the Type of the Series holding time.Time elements. The zero time is
+// considered NaN.
+//
+// Series elements must be ordered, which time.Time is not, so Time Series are
+// stored as a Series[int64] holding the number of nanoseconds elapsed since the
+// Unix epoch. Comparing, sorting, Min and Max then follow the chronological
+// order of the times. Only times between the years 1678 and 2262 can be held.
+const Time Type = "time"
+
+// TimeFormat is the layout used to parse Time elements from strings and to
+// format them back.
+const TimeFormat = time.RFC3339Nano
+
+// Times is a constructor for a Time Series
+func Times(values ...time.Time) Series[int64] {
+	elements := make([]Element[int64], len(values))
+	for i, t := range values {
+		elements[i] = NewTimeElement(t)
+	}
+	return &GotaSeries[int64]{elements: elementsOf(elements)}
+}
+
+// ParseTimes is a constructor for a Time Series parsing the given strings with
+// the given layout, as understood by time.Parse. Strings that can't be parsed
+// result in NaN elements.
+func ParseTimes(layout string, values ...string) Series[int64] {
+	elements := make([]Element[int64], len(values))
+	for i, str := range values {
+		t, err := time.Parse(layout, str)
+		if err != nil {
+			elements[i] = NewNAElement[int64]()
+			continue
+		}
+		elements[i] = NewTimeElement(t)
+	}
+	return &GotaSeries[int64]{elements: elementsOf(elements)}
+}
+
+// NewTimeElement returns an Element holding the given time. The zero time
+// results in a NaN Element.
+func NewTimeElement(t time.Time) Element[int64] {
+	if t.IsZero() {
+		return NewNAElement[int64]()
+	}
+	return NewElement(t.UnixNano())
+}
+
+// ElementTime returns the time held by an Element of a Time Series, in UTC.
+// The second return value is false if the Element is NaN.
+func ElementTime(e Element[int64]) (time.Time, bool) {
+	if e.IsNA() {
+		return time.Time{}, false
+	}
+	return time.Unix(0, e.Val()).UTC(), true
+}
+
+// TimeRecords returns the elements of a Time Series formatted with
+// TimeFormat, with "NaN" for NaN elements.
+func TimeRecords(s Series[int64]) []string {
+	ret := make([]string, s.Len())
+	for i := range ret {
+		t, ok := ElementTime(s.Elem(i))
+		if !ok {
+			ret[i] = "NaN"
+			continue
+		}
+		ret[i] = t.Format(TimeFormat)
+	}
+	return ret
+}